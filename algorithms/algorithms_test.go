@@ -1,11 +1,53 @@
 package algorithms
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
+type testRecord struct {
+	Key     int
+	Payload [7]int64
+}
+
+func compareRecords(a, b testRecord) int {
+	return cmp.Compare(a.Key, b.Key)
+}
+
+var genericFloatSorts = map[string]func([]float64) []float64{
+	"BubbleSort":    BubbleSortOrdered[float64],
+	"InsertionSort": InsertionSortOrdered[float64],
+	"MergeSort":     MergeSortOrdered[float64],
+	"QuickSort":     QuickSortOrdered[float64],
+	"HeapSort":      HeapSortOrdered[float64],
+	"NativeSort":    NativeSortOrdered[float64],
+	"IntroSort":     IntroSortOrdered[float64],
+	"PdqSort":       PdqSortOrdered[float64],
+	"TimSort":       TimSortOrdered[float64],
+}
+
+var genericRecordSorts = map[string]func([]testRecord, func(a, b testRecord) int) []testRecord{
+	"BubbleSort":    BubbleSortFunc[testRecord],
+	"InsertionSort": InsertionSortFunc[testRecord],
+	"MergeSort":     MergeSortFunc[testRecord],
+	"QuickSort":     QuickSortFunc[testRecord],
+	"HeapSort":      HeapSortFunc[testRecord],
+	"NativeSort":    NativeSortFunc[testRecord],
+	"IntroSort":     IntroSortFunc[testRecord],
+	"PdqSort":       PdqSortFunc[testRecord],
+	"TimSort":       TimSortFunc[testRecord],
+}
+
 func TestLinearSearch(t *testing.T) {
 	arr := []int{1, 3, 5, 7, 9, 11, 13, 15}
 	
@@ -50,6 +92,81 @@ func TestBinarySearch(t *testing.T) {
 	}
 }
 
+func TestSortedSearches(t *testing.T) {
+	searches := map[string]func([]int, int) int{
+		"BinarySearch":        BinarySearch,
+		"InterpolationSearch": InterpolationSearch,
+		"ExponentialSearch":   ExponentialSearch,
+		"JumpSearch":          JumpSearch,
+		"TernarySearch":       TernarySearch,
+		"FibonacciSearch":     FibonacciSearch,
+		"BranchlessSearch":    BranchlessSearch,
+		"EytzingerLayout":     func(arr []int, target int) int { return NewEytzingerLayout(arr).Search(target) },
+		"BTreeLayout":         func(arr []int, target int) int { return NewBTreeLayout(arr).Search(target) },
+	}
+	
+	inputs := [][]int{
+		{},
+		{7},
+		{1, 3, 5, 7, 9, 11, 13, 15},
+		{-40, -3, 0, 2, 2, 2, 9, 100, 1000, 1 << 40},
+		{4, 4, 4, 4},
+	}
+	
+	rng := rand.New(rand.NewSource(1))
+	for n := 1; n <= 64; n++ {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(3 * n)
+		}
+		sort.Ints(arr)
+		inputs = append(inputs, arr)
+	}
+	
+	for name, search := range searches {
+		for _, arr := range inputs {
+			for target := -50; target <= 200; target++ {
+				index := search(arr, target)
+				expected := LinearSearch(arr, target)
+				if expected == -1 && index != -1 {
+					t.Errorf("%s(%v, %d) = %d, expected -1", name, arr, target, index)
+				} else if expected != -1 && (index < 0 || index >= len(arr) || arr[index] != target) {
+					t.Errorf("%s(%v, %d) = %d, expected an index of %d", name, arr, target, index, target)
+				}
+			}
+		}
+	}
+}
+
+func TestLayoutLowerBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	
+	for _, n := range []int{0, 1, 2, 15, 16, 17, 100, 289, 1000} {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(2 * n + 1)
+		}
+		sort.Ints(arr)
+		
+		eytzinger := NewEytzingerLayout(arr)
+		bTree := NewBTreeLayout(arr)
+		
+		for target := -1; target <= 2*n+2; target++ {
+			expected := sort.SearchInts(arr, target)
+			
+			if index := BranchlessLowerBound(arr, target); index != expected {
+				t.Errorf("BranchlessLowerBound(n=%d, %d) = %d, expected %d", n, target, index, expected)
+			}
+			if index := eytzinger.LowerBound(target); index != expected {
+				t.Errorf("EytzingerLayout.LowerBound(n=%d, %d) = %d, expected %d", n, target, index, expected)
+			}
+			if index := bTree.LowerBound(target); index != expected {
+				t.Errorf("BTreeLayout.LowerBound(n=%d, %d) = %d, expected %d", n, target, index, expected)
+			}
+		}
+	}
+}
+
 func TestBinarySearchUnsorted(t *testing.T) {
 	arr := []int{5, 1, 9, 3, 7, 11, 15, 13}
 	
@@ -72,6 +189,70 @@ func TestBinarySearchUnsorted(t *testing.T) {
 	}
 }
 
+func TestBinarySearchUnsortedDuplicates(t *testing.T) {
+	arr := []int{8, 3, 8, 1, 3, 3, 8, 0}
+	
+	for _, target := range []int{0, 1, 3, 8} {
+		if result := BinarySearchUnsorted(arr, target); result != LinearSearch(arr, target) {
+			t.Errorf("BinarySearchUnsorted(%v, %d) = %d, expected first occurrence %d", arr, target, result, LinearSearch(arr, target))
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	inputs := [][]int{
+		{},
+		{5},
+		{1, 1, 1, 1},
+		{1, 2, 2, 2, 3, 5, 5, 8},
+	}
+	
+	rng := rand.New(rand.NewSource(3))
+	for n := 1; n <= 40; n++ {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = rng.Intn(n / 2 + 1)
+		}
+		sort.Ints(arr)
+		inputs = append(inputs, arr)
+	}
+	
+	for _, arr := range inputs {
+		for target := -1; target <= 25; target++ {
+			lower := sort.SearchInts(arr, target)
+			upper := sort.SearchInts(arr, target+1)
+			
+			if index := LowerBound(arr, target); index != lower {
+				t.Errorf("LowerBound(%v, %d) = %d, expected %d", arr, target, index, lower)
+			}
+			if index := UpperBound(arr, target); index != upper {
+				t.Errorf("UpperBound(%v, %d) = %d, expected %d", arr, target, index, upper)
+			}
+			if first, last := EqualRange(arr, target); first != lower || last != upper {
+				t.Errorf("EqualRange(%v, %d) = (%d, %d), expected (%d, %d)", arr, target, first, last, lower, upper)
+			}
+			
+			expected := -1
+			if lower < upper {
+				expected = lower
+			}
+			if index := BinarySearch(arr, target); index != expected {
+				t.Errorf("BinarySearch(%v, %d) = %d, expected leftmost match %d", arr, target, index, expected)
+			}
+		}
+	}
+	
+	words := []string{"ant", "bee", "bee", "cat"}
+	if first, last := EqualRangeOrdered(words, "bee"); first != 1 || last != 3 {
+		t.Errorf("EqualRangeOrdered(%v, bee) = (%d, %d), expected (1, 3)", words, first, last)
+	}
+	
+	records := []testRecord{{Key: 1}, {Key: 4}, {Key: 4}, {Key: 9}}
+	if index := UpperBoundFunc(records, testRecord{Key: 4}, compareRecords); index != 3 {
+		t.Errorf("UpperBoundFunc on records = %d, expected 3", index)
+	}
+}
+
 func TestBubbleSort(t *testing.T) {
 	arr := []int{64, 34, 25, 12, 22, 11, 90}
 	expected := []int{11, 12, 22, 25, 34, 64, 90}
@@ -155,6 +336,13 @@ func TestSortingAlgorithmsConsistency(t *testing.T) {
 		QuickSort,
 		HeapSort,
 		NativeSort,
+		RadixSortLSD,
+		RadixSortMSD,
+		CountingSort,
+		BucketSort,
+		IntroSort,
+		PdqSort,
+		TimSort,
 	}
 	
 	for _, testCase := range testCases {
@@ -179,6 +367,13 @@ func TestEmptyAndSingleElement(t *testing.T) {
 		QuickSort,
 		HeapSort,
 		NativeSort,
+		RadixSortLSD,
+		RadixSortMSD,
+		CountingSort,
+		BucketSort,
+		IntroSort,
+		PdqSort,
+		TimSort,
 	}
 	
 	testCases := [][]int{
@@ -199,3 +394,776 @@ func TestEmptyAndSingleElement(t *testing.T) {
 		}
 	}
 }
+
+func TestIntegerSortsWithNegatives(t *testing.T) {
+	testCases := [][]int{
+		{-5, 3, -1, 0, 2, -5, 7, -100, 100},
+		{-1, -2, -3, -4, -5},
+		{0, 0, 0, 0},
+		{math.MaxInt64, math.MinInt64, 0, -1, 1},
+	}
+	
+	algorithms := map[string]func([]int) []int{
+		"RadixSortLSD": RadixSortLSD,
+		"RadixSortMSD": RadixSortMSD,
+		"BucketSort":   BucketSort,
+		"RadixSortLSD(4 bits)": func(arr []int) []int {
+			return RadixSortLSDWithWidth(arr, 4)
+		},
+		"RadixSortLSD(16 bits)": func(arr []int) []int {
+			return RadixSortLSDWithWidth(arr, 16)
+		},
+		"RadixSortMSD(no cutoff)": func(arr []int) []int {
+			return RadixSortMSDWithCutoff(arr, 1)
+		},
+	}
+	
+	for _, testCase := range testCases {
+		expected := make([]int, len(testCase))
+		copy(expected, testCase)
+		sort.Ints(expected)
+		
+		for name, algorithm := range algorithms {
+			result := algorithm(testCase)
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("%s failed for input %v: got %v, expected %v", name, testCase, result, expected)
+			}
+		}
+	}
+	
+	arr := []int{-5, 3, -1, 0, 2, -5, 7, -100, 100}
+	expected := []int{-100, -5, -5, -1, 0, 2, 3, 7, 100}
+	if result := CountingSort(arr); !reflect.DeepEqual(result, expected) {
+		t.Errorf("CountingSort(%v) = %v, expected %v", arr, result, expected)
+	}
+}
+
+func TestIntegerSortsLargeInput(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]int, 5000)
+	for i := range arr {
+		arr[i] = rng.Intn(1<<20) - 1<<19
+	}
+	
+	expected := make([]int, len(arr))
+	copy(expected, arr)
+	sort.Ints(expected)
+	
+	for _, algorithm := range []func([]int) []int{RadixSortLSD, RadixSortMSD, CountingSort, BucketSort} {
+		if result := algorithm(arr); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Integer sort produced an unsorted result for a large input")
+		}
+	}
+}
+
+func TestCountingSortWideRange(t *testing.T) {
+	for _, arr := range [][]int{
+		{math.MaxInt64, 0, math.MinInt64, -1, 1},
+		{1 << 40, 0, 1 << 40, 0},
+		{math.MinInt64, math.MaxInt64},
+	} {
+		expected := make([]int, len(arr))
+		copy(expected, arr)
+		sort.Ints(expected)
+		
+		if result := CountingSort(arr); !reflect.DeepEqual(result, expected) {
+			t.Errorf("CountingSort(%v) = %v, expected %v", arr, result, expected)
+		}
+		
+		var counter Counter
+		if result := CountingSortCounted(arr, &counter); !reflect.DeepEqual(result, expected) {
+			t.Errorf("CountingSortCounted(%v) = %v, expected %v", arr, result, expected)
+		}
+	}
+	
+	wide := []int64{math.MaxInt64, math.MinInt64, 0}
+	if result := CountingSortInteger(wide); !slices.IsSorted(result) {
+		t.Errorf("CountingSortInteger(%v) = %v", wide, result)
+	}
+}
+
+func TestHybridSortsOnPatterns(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	
+	patterns := map[string]func(n int) []int{
+		"random": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = rng.Intn(n * 2)
+			}
+			return arr
+		},
+		"sorted": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = i
+			}
+			return arr
+		},
+		"reverse": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = n - i
+			}
+			return arr
+		},
+		"few_unique": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = rng.Intn(4)
+			}
+			return arr
+		},
+		"organ_pipe": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				if i < n/2 {
+					arr[i] = i
+				} else {
+					arr[i] = n - i
+				}
+			}
+			return arr
+		},
+		"runs": func(n int) []int {
+			arr := make([]int, n)
+			for i := range arr {
+				arr[i] = i % 97
+			}
+			return arr
+		},
+	}
+	
+	algorithms := map[string]func([]int) []int{
+		"IntroSort": IntroSort,
+		"PdqSort":   PdqSort,
+		"TimSort":   TimSort,
+	}
+	
+	for _, n := range []int{17, 100, 1000, 50000} {
+		for patternName, pattern := range patterns {
+			arr := pattern(n)
+			expected := make([]int, len(arr))
+			copy(expected, arr)
+			sort.Ints(expected)
+			
+			for name, algorithm := range algorithms {
+				if result := algorithm(arr); !reflect.DeepEqual(result, expected) {
+					t.Errorf("%s failed on %s input of size %d", name, patternName, n)
+				}
+			}
+		}
+	}
+}
+
+func TestQuickSortVariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	
+	inputs := [][]int{
+		{},
+		{1},
+		{2, 1},
+		{5, 5, 5, 5, 5, 5},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	
+	for _, n := range []int{50, 500} {
+		random := make([]int, n)
+		fewUnique := make([]int, n)
+		sorted := make([]int, n)
+		for i := 0; i < n; i++ {
+			random[i] = rng.Intn(n)
+			fewUnique[i] = rng.Intn(3)
+			sorted[i] = i
+		}
+		inputs = append(inputs, random, fewUnique, sorted)
+	}
+	
+	for _, pivot := range GetAllPivotStrategies() {
+		for _, partition := range GetAllPartitionSchemes() {
+			options := SortOptions{Pivot: pivot, Partition: partition, Rand: rand.New(rand.NewSource(1))}
+			
+			for _, input := range inputs {
+				expected := make([]int, len(input))
+				copy(expected, input)
+				sort.Ints(expected)
+				
+				result := QuickSortWithOptions(input, options)
+				if !reflect.DeepEqual(result, expected) {
+					t.Errorf("QuickSort(%s/%s) failed for input %v: got %v",
+						GetPivotStrategyName(pivot), GetPartitionSchemeName(partition), input, result)
+				}
+			}
+		}
+	}
+}
+
+func TestParseQuickSortOptions(t *testing.T) {
+	for _, pivot := range GetAllPivotStrategies() {
+		parsed, err := ParsePivotStrategy(GetPivotStrategyName(pivot))
+		if err != nil || parsed != pivot {
+			t.Errorf("ParsePivotStrategy(%s) = %v, %v", GetPivotStrategyName(pivot), parsed, err)
+		}
+	}
+	
+	for _, scheme := range GetAllPartitionSchemes() {
+		parsed, err := ParsePartitionScheme(GetPartitionSchemeName(scheme))
+		if err != nil || parsed != scheme {
+			t.Errorf("ParsePartitionScheme(%s) = %v, %v", GetPartitionSchemeName(scheme), parsed, err)
+		}
+	}
+	
+	if _, err := ParsePivotStrategy("middle"); err == nil {
+		t.Error("Expected error for unknown pivot strategy")
+	}
+	if _, err := ParsePartitionScheme("bentley"); err == nil {
+		t.Error("Expected error for unknown partition scheme")
+	}
+}
+
+func TestGenericSortsFloat64(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]float64, 300)
+	for i := range arr {
+		arr[i] = rng.NormFloat64()
+	}
+	arr[10] = math.NaN()
+	arr[20] = math.Inf(-1)
+	arr[30] = math.Inf(1)
+	
+	expected := make([]float64, len(arr))
+	copy(expected, arr)
+	slices.Sort(expected)
+	
+	for name, algorithm := range genericFloatSorts {
+		result := algorithm(arr)
+		if !slices.EqualFunc(result, expected, func(a, b float64) bool { return cmp.Compare(a, b) == 0 }) {
+			t.Errorf("%s failed to sort float64 input", name)
+		}
+	}
+}
+
+func TestGenericSortsStrings(t *testing.T) {
+	arr := strings.Fields("pear apple fig banana cherry apple date elderberry grape fig")
+	expected := make([]string, len(arr))
+	copy(expected, arr)
+	sort.Strings(expected)
+	
+	if result := QuickSortOrdered(arr); !reflect.DeepEqual(result, expected) {
+		t.Errorf("QuickSortOrdered(%v) = %v, expected %v", arr, result, expected)
+	}
+	if result := TimSortOrdered(arr); !reflect.DeepEqual(result, expected) {
+		t.Errorf("TimSortOrdered(%v) = %v, expected %v", arr, result, expected)
+	}
+	
+	reversed := MergeSortFunc(arr, func(a, b string) int { return strings.Compare(b, a) })
+	for i := 1; i < len(reversed); i++ {
+		if reversed[i-1] < reversed[i] {
+			t.Errorf("MergeSortFunc with reversed comparator returned %v", reversed)
+			break
+		}
+	}
+}
+
+func TestGenericSortsRecords(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]testRecord, 200)
+	for i := range arr {
+		arr[i] = testRecord{Key: rng.Intn(50)}
+		arr[i].Payload[0] = int64(i)
+	}
+	
+	for name, algorithm := range genericRecordSorts {
+		result := algorithm(arr, compareRecords)
+		if len(result) != len(arr) || !slices.IsSortedFunc(result, compareRecords) {
+			t.Errorf("%s failed to sort records by key", name)
+		}
+	}
+}
+
+func TestGenericIntegerSorts(t *testing.T) {
+	arr := []int8{-128, 127, 0, -1, 1, 5, -5, 5}
+	expected := []int8{-128, -5, -1, 0, 1, 5, 5, 127}
+	
+	if result := RadixSortLSDInteger(arr, 4); !reflect.DeepEqual(result, expected) {
+		t.Errorf("RadixSortLSDInteger(%v) = %v, expected %v", arr, result, expected)
+	}
+	if result := RadixSortMSDInteger(arr, 1); !reflect.DeepEqual(result, expected) {
+		t.Errorf("RadixSortMSDInteger(%v) = %v, expected %v", arr, result, expected)
+	}
+	if result := CountingSortInteger(arr); !reflect.DeepEqual(result, expected) {
+		t.Errorf("CountingSortInteger(%v) = %v, expected %v", arr, result, expected)
+	}
+	if result := BucketSortInteger(arr); !reflect.DeepEqual(result, expected) {
+		t.Errorf("BucketSortInteger(%v) = %v, expected %v", arr, result, expected)
+	}
+	
+	unsigned := []uint64{math.MaxUint64, 0, 1 << 63, 42}
+	if result := RadixSortLSDInteger(unsigned, 8); !slices.IsSorted(result) {
+		t.Errorf("RadixSortLSDInteger(%v) = %v", unsigned, result)
+	}
+}
+
+func TestGenericSearches(t *testing.T) {
+	words := []string{"apple", "banana", "cherry", "date"}
+	
+	for name, search := range map[string]func([]string, string) int{
+		"ExponentialSearchOrdered": ExponentialSearchOrdered[string],
+		"JumpSearchOrdered":        JumpSearchOrdered[string],
+		"TernarySearchOrdered":     TernarySearchOrdered[string],
+		"FibonacciSearchOrdered":   FibonacciSearchOrdered[string],
+	} {
+		if index := search(words, "banana"); index != 1 {
+			t.Errorf("%s(%v, banana) = %d, expected 1", name, words, index)
+		}
+	}
+	
+	if index := BranchlessSearchOrdered(words, "date"); index != 3 {
+		t.Errorf("BranchlessSearchOrdered(%v, date) = %d, expected 3", words, index)
+	}
+	if index := NewEytzingerLayout(words).Search("apple"); index != 0 {
+		t.Errorf("EytzingerLayout.Search(%v, apple) = %d, expected 0", words, index)
+	}
+	if index := NewBTreeLayout(words).Search("fig"); index != -1 {
+		t.Errorf("BTreeLayout.Search(%v, fig) = %d, expected -1", words, index)
+	}
+	
+	if index := InterpolationSearchNumber([]float64{0.5, 1.5, 2.5, 3.5}, 2.5); index != 2 {
+		t.Errorf("InterpolationSearchNumber = %d, expected 2", index)
+	}
+	
+	if index := BinarySearchOrdered(words, "cherry"); index != 2 {
+		t.Errorf("BinarySearchOrdered(%v, cherry) = %d, expected 2", words, index)
+	}
+	if index := LinearSearchOrdered(words, "kiwi"); index != -1 {
+		t.Errorf("LinearSearchOrdered(%v, kiwi) = %d, expected -1", words, index)
+	}
+	
+	records := []testRecord{{Key: 1}, {Key: 3}, {Key: 5}, {Key: 7}}
+	if index := BinarySearchFunc(records, testRecord{Key: 5}, compareRecords); index != 2 {
+		t.Errorf("BinarySearchFunc on records = %d, expected 2", index)
+	}
+	
+	if value, ok := QuickSelectOrdered([]float64{3.5, 1.25, 2.75}, 1); !ok || value != 2.75 {
+		t.Errorf("QuickSelectOrdered = %v, expected 2.75", value)
+	}
+}
+
+func TestQuickSelect(t *testing.T) {
+	arr := []int{64, 34, 25, 12, 22, 11, 90}
+	sorted := []int{11, 12, 22, 25, 34, 64, 90}
+	
+	for k, expected := range sorted {
+		result, ok := QuickSelect(arr, k)
+		if !ok || result != expected {
+			t.Errorf("QuickSelect(%v, %d) = %d, %t, expected %d", arr, k, result, ok, expected)
+		}
+	}
+	
+	for _, k := range []int{-1, len(arr)} {
+		if _, ok := QuickSelect(arr, k); ok {
+			t.Errorf("QuickSelect with out of range k = %d succeeded", k)
+		}
+		if _, ok := QuickSelectCounted(arr, k, &Counter{}); ok {
+			t.Errorf("QuickSelectCounted with out of range k = %d succeeded", k)
+		}
+		if _, ok := QuickSelectOrdered(arr, k); ok {
+			t.Errorf("QuickSelectOrdered with out of range k = %d succeeded", k)
+		}
+		if _, ok := QuickSelectFunc(arr, k, cmp.Compare[int]); ok {
+			t.Errorf("QuickSelectFunc with out of range k = %d succeeded", k)
+		}
+	}
+	
+	for _, n := range []int{1, 2, 3, 4, 10} {
+		for k := 0; k < n; k++ {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = (i * 7) % n
+			}
+			if result, _ := QuickSelect(values, k); result != k {
+				t.Errorf("QuickSelect(%v, %d) = %d, expected %d", values, k, result, k)
+			}
+			if result, _ := QuickSelectFunc(values, k, cmp.Compare[int]); result != k {
+				t.Errorf("QuickSelectFunc(%v, %d) = %d, expected %d", values, k, result, k)
+			}
+			var counter Counter
+			if result, _ := QuickSelectCounted(values, k, &counter); result != k {
+				t.Errorf("QuickSelectCounted(%v, %d) = %d, expected %d", values, k, result, k)
+			}
+		}
+	}
+	
+	ascending := make([]int, 100000)
+	for i := range ascending {
+		ascending[i] = i
+	}
+	var counter Counter
+	if result, _ := QuickSelectCounted(ascending, len(ascending)/3, &counter); result != len(ascending)/3 {
+		t.Errorf("QuickSelectCounted on sorted input = %d, expected %d", result, len(ascending)/3)
+	}
+	if counter.Comparisons > uint64(10*len(ascending)) {
+		t.Errorf("QuickSelect made %d comparisons on sorted input, expected a linear number", counter.Comparisons)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	expected := map[Kind][]string{
+		Search: {"linear_search", "binary_search", "interpolation_search", "exponential_search", "jump_search", "ternary_search", "fibonacci_search", "branchless_search", "eytzinger_search", "btree_search"},
+		Sort:   {"bubble_sort", "insertion_sort", "merge_sort", "quick_sort", "heap_sort", "native_sort", "radix_sort_lsd", "radix_sort_msd", "counting_sort", "bucket_sort", "intro_sort", "pdq_sort", "tim_sort"},
+		Select: {"quick_select"},
+	}
+	
+	for kind, names := range expected {
+		for _, name := range names {
+			algorithm, ok := Get(name)
+			if !ok {
+				t.Errorf("Expected %s to be registered", name)
+				continue
+			}
+			if algorithm.Kind != kind {
+				t.Errorf("Expected %s to be of kind %s, got %s", name, GetKindName(kind), GetKindName(algorithm.Kind))
+			}
+		}
+	}
+	
+	for _, algorithm := range ByKind(Sort) {
+		result := algorithm.Sort([]int{3, 1, 2})
+		if !reflect.DeepEqual(result, []int{1, 2, 3}) {
+			t.Errorf("Registered sort %s returned %v", algorithm.Name, result)
+		}
+	}
+	
+	if _, ok := Get("no_such_algorithm"); ok {
+		t.Error("Expected unknown algorithm lookup to fail")
+	}
+}
+
+func TestCountedAlgorithmsMatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	arr := make([]int, 500)
+	for i := range arr {
+		arr[i] = rng.Intn(200)
+	}
+	sorted := NativeSort(arr)
+	
+	for _, algorithm := range All() {
+		var counter Counter
+		switch algorithm.Kind {
+		case Search:
+			if algorithm.Layout != nil {
+				if algorithm.Search != nil || algorithm.CountedSearch != nil {
+					t.Errorf("%s exposes a per-call search next to its layout", algorithm.Name)
+				}
+				query, counted := algorithm.Layout(sorted, nil), algorithm.Layout(sorted, &counter)
+				for _, target := range []int{sorted[0], sorted[250], sorted[499], -1, 1000} {
+					if result := counted(target); result != query(target) {
+						t.Errorf("%s counted layout search for %d = %d, expected %d", algorithm.Name, target, result, query(target))
+					}
+				}
+				if counter.Comparisons == 0 {
+					t.Errorf("%s counted layout search made no comparisons", algorithm.Name)
+				}
+				continue
+			}
+			if algorithm.CountedSearch == nil {
+				t.Errorf("%s has no counted search", algorithm.Name)
+				continue
+			}
+			for _, target := range []int{sorted[0], sorted[250], sorted[499], -1, 1000} {
+				expected := algorithm.Search(sorted, target)
+				if result := algorithm.CountedSearch(sorted, target, &counter); result != expected {
+					t.Errorf("%s counted search for %d = %d, expected %d", algorithm.Name, target, result, expected)
+				}
+			}
+		case Sort:
+			if algorithm.CountedSort == nil {
+				t.Errorf("%s has no counted sort", algorithm.Name)
+				continue
+			}
+			if result := algorithm.CountedSort(arr, &counter); !reflect.DeepEqual(result, sorted) {
+				t.Errorf("%s counted sort returned an unsorted result", algorithm.Name)
+			}
+		case Select:
+			if algorithm.CountedSelect == nil {
+				t.Errorf("%s has no counted select", algorithm.Name)
+				continue
+			}
+			if result, _ := algorithm.CountedSelect(arr, 100, &counter); result != sorted[100] {
+				t.Errorf("%s counted select = %d, expected %d", algorithm.Name, result, sorted[100])
+			}
+		}
+		
+		if counter.Reads == 0 {
+			t.Errorf("%s recorded no reads", algorithm.Name)
+		}
+	}
+}
+
+func TestOperationCounts(t *testing.T) {
+	const n = 100
+	ascending := make([]int, n)
+	descending := make([]int, n)
+	for i := range ascending {
+		ascending[i] = i
+		descending[i] = n - i
+	}
+	
+	var counter Counter
+	BubbleSortCounted(descending, &counter)
+	if counter.Comparisons != n*(n-1)/2 || counter.Swaps != n*(n-1)/2 {
+		t.Errorf("BubbleSort on reversed input: %+v, expected %d comparisons and swaps", counter, n*(n-1)/2)
+	}
+	
+	counter.Reset()
+	BubbleSortCounted(ascending, &counter)
+	if counter.Comparisons != n-1 || counter.Swaps != 0 || counter.Writes != 0 {
+		t.Errorf("BubbleSort on sorted input: %+v, expected %d comparisons and no writes", counter, n-1)
+	}
+	
+	counter.Reset()
+	InsertionSortCounted(ascending, &counter)
+	if counter.Comparisons != n-1 {
+		t.Errorf("InsertionSort on sorted input: %d comparisons, expected %d", counter.Comparisons, n-1)
+	}
+	
+	counter.Reset()
+	MergeSortCounted(descending, &counter)
+	if bound := uint64(n * math.Ceil(math.Log2(n))); counter.Comparisons > bound {
+		t.Errorf("MergeSort: %d comparisons, expected at most %d", counter.Comparisons, bound)
+	}
+	
+	limit := uint64(math.Ceil(math.Log2(n))) + 2
+	for target := -1; target <= n; target++ {
+		counter.Reset()
+		BinarySearchCounted(ascending, target, &counter)
+		if counter.Comparisons > limit {
+			t.Errorf("BinarySearch for %d: %d comparisons, expected at most %d", target, counter.Comparisons, limit)
+		}
+	}
+	
+	counter.Reset()
+	LinearSearchCounted(ascending, n-1, &counter)
+	if counter.Comparisons != n || counter.Reads != n {
+		t.Errorf("LinearSearch for last element: %+v, expected %d comparisons and reads", counter, n)
+	}
+	
+	counter.Reset()
+	CountingSortCounted(descending, &counter)
+	if counter.Comparisons != 2*(n-1) || counter.Swaps != 0 {
+		t.Errorf("CountingSort: %+v, expected only min/max comparisons", counter)
+	}
+}
+
+func TestTraceReplay(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	arr := make([]int, 150)
+	for i := range arr {
+		arr[i] = rng.Intn(1000) - 500
+	}
+	
+	for _, algorithm := range ByKind(Sort) {
+		trace, err := TraceSort(algorithm.Name, arr, SortOptions{}, 0)
+		if err != nil {
+			t.Errorf("TraceSort(%s) failed: %v", algorithm.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(trace.Output, NativeSort(arr)) {
+			t.Errorf("%s traced sort returned an unsorted result", algorithm.Name)
+		}
+		if trace.Truncated {
+			t.Errorf("%s trace was truncated", algorithm.Name)
+		}
+		if replayed := ReplayTrace(trace); !reflect.DeepEqual(replayed, trace.Output) {
+			t.Errorf("%s trace replay does not reproduce the sorted output", algorithm.Name)
+		}
+		
+		depth := 0
+		for _, event := range trace.Events {
+			if event.I < 0 || event.I >= len(arr) || event.J < -1 || event.J > len(arr) {
+				t.Errorf("%s trace event %+v is out of range", algorithm.Name, event)
+				break
+			}
+			switch event.Type {
+			case TraceEnter:
+				depth++
+			case TraceExit:
+				depth--
+			}
+		}
+		if depth != 0 {
+			t.Errorf("%s trace has unbalanced enter/exit events", algorithm.Name)
+		}
+	}
+	
+	for _, options := range []SortOptions{{Pivot: PivotMedianOfThree, Partition: PartitionHoare}, {Partition: PartitionThreeWay}} {
+		trace, err := TraceSort("quick_sort", arr, options, 0)
+		if err != nil {
+			t.Fatalf("TraceSort(quick_sort) failed: %v", err)
+		}
+		if replayed := ReplayTrace(trace); !reflect.DeepEqual(replayed, trace.Output) {
+			t.Errorf("quick_sort %s/%s trace replay does not reproduce the sorted output",
+				GetPivotStrategyName(options.Pivot), GetPartitionSchemeName(options.Partition))
+		}
+	}
+}
+
+func TestTraceSortErrors(t *testing.T) {
+	trace, err := TraceSort("bubble_sort", []int{5, 4, 3, 2, 1}, SortOptions{}, 3)
+	if err != nil {
+		t.Fatalf("TraceSort failed: %v", err)
+	}
+	if len(trace.Events) != 3 || !trace.Truncated {
+		t.Errorf("Expected 3 events and a truncated trace, got %d events (truncated=%v)", len(trace.Events), trace.Truncated)
+	}
+	
+	if _, err := TraceSort("binary_search", []int{1}, SortOptions{}, 0); err == nil {
+		t.Error("Expected TraceSort to reject a search algorithm")
+	}
+	if _, err := TraceSort("heap_sort", []int{1}, SortOptions{Partition: PartitionHoare}, 0); err == nil {
+		t.Error("Expected TraceSort to reject quicksort options for heap sort")
+	}
+}
+
+func TestComparisonSort(t *testing.T) {
+	input := []int{5, 2, 8, 1, 9, 3}
+	expected := []int{1, 2, 3, 5, 8, 9}
+	
+	for _, options := range []SortOptions{{}, {Pivot: PivotMedianOfThree, Partition: PartitionHoare}} {
+		sortFunc, err := ComparisonSort("quick_sort", options)
+		if err != nil {
+			t.Fatalf("ComparisonSort failed: %v", err)
+		}
+		if result := sortFunc(input, cmp.Compare[int]); !reflect.DeepEqual(result, expected) {
+			t.Errorf("ComparisonSort(quick_sort, %+v) = %v, expected %v", options, result, expected)
+		}
+	}
+	
+	if _, err := ComparisonSort("radix_sort_lsd", SortOptions{}); err == nil {
+		t.Error("Expected ComparisonSort to reject an integer sort")
+	}
+	if _, err := ComparisonSort("heap_sort", SortOptions{Pivot: PivotRandom}); err == nil {
+		t.Error("Expected ComparisonSort to reject quicksort options for heap sort")
+	}
+	if _, err := ComparisonSort("binary_search", SortOptions{}); err == nil {
+		t.Error("Expected ComparisonSort to reject a search algorithm")
+	}
+}
+
+func TestInterruptibleSorts(t *testing.T) {
+	arr := rand.New(rand.NewSource(1)).Perm(1000)
+	
+	for _, algorithm := range ByKind(Sort) {
+		sortFunc := algorithm.Interruptible
+		if algorithm.SortWithOptions != nil {
+			sortFunc = algorithm.SortWithOptions
+		}
+		if sortFunc == nil {
+			continue
+		}
+		
+		if result := sortFunc(arr, SortOptions{Interrupt: &Interrupt{}}); !slices.IsSorted(result) {
+			t.Errorf("%s did not sort with a running interrupt", algorithm.Name)
+		}
+		
+		stopped := &Interrupt{}
+		stopped.Stop()
+		err := (&Interrupt{}).Run(func() {
+			sortFunc(arr, SortOptions{Interrupt: stopped})
+		})
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("%s was not interrupted: %v", algorithm.Name, err)
+		}
+	}
+}
+
+func TestWatchContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt, release := WatchContext(ctx)
+	defer release()
+	
+	if interrupt.Stopped() {
+		t.Fatal("Interrupt stopped before the context was cancelled")
+	}
+	if err := interrupt.Run(func() {}); err != nil {
+		t.Errorf("Run failed: %v", err)
+	}
+	
+	cancel()
+	for i := 0; i < 1000 && !interrupt.Stopped(); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if !interrupt.Stopped() {
+		t.Fatal("Interrupt not stopped after the context was cancelled")
+	}
+	if err := interrupt.Run(func() { t.Error("Run called a stopped function") }); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Expected ErrInterrupted, got %v", err)
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Register to panic on duplicate name")
+		}
+	}()
+	
+	Register(Algorithm{Name: "bubble_sort", Kind: Sort, Sort: BubbleSort})
+}
+
+func BenchmarkSortFloat64(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]float64, 10000)
+	for i := range arr {
+		arr[i] = rng.Float64()
+	}
+	
+	for _, name := range []string{"MergeSort", "QuickSort", "HeapSort", "NativeSort", "PdqSort", "TimSort"} {
+		algorithm := genericFloatSorts[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				algorithm(arr)
+			}
+		})
+	}
+}
+
+func BenchmarkSortString(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]string, 10000)
+	for i := range arr {
+		arr[i] = fmt.Sprintf("%016x", rng.Uint64())
+	}
+	
+	algorithms := map[string]func([]string) []string{
+		"MergeSort":  MergeSortOrdered[string],
+		"QuickSort":  QuickSortOrdered[string],
+		"NativeSort": NativeSortOrdered[string],
+		"TimSort":    TimSortOrdered[string],
+	}
+	
+	for name, algorithm := range algorithms {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				algorithm(arr)
+			}
+		})
+	}
+}
+
+func BenchmarkSortRecord(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	arr := make([]testRecord, 10000)
+	for i := range arr {
+		arr[i] = testRecord{Key: rng.Int()}
+	}
+	
+	for _, name := range []string{"MergeSort", "QuickSort", "NativeSort", "PdqSort", "TimSort"} {
+		algorithm := genericRecordSorts[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				algorithm(arr, compareRecords)
+			}
+		})
+	}
+}
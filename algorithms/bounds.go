@@ -0,0 +1,99 @@
+package algorithms
+
+import "cmp"
+
+func LowerBound(arr []int, target int) int {
+	return LowerBoundOrdered(arr, target)
+}
+
+func LowerBoundOrdered[T cmp.Ordered](arr []T, target T) int {
+	return lowerBound(arr, target)
+}
+
+func LowerBoundFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return lowerBoundFunc(arr, target, compare)
+}
+
+func lowerBound[T cmp.Ordered](arr []T, target T) int {
+	low, high := 0, len(arr)
+	
+	for low < high {
+		mid := low + (high-low)/2
+		if cmp.Less(arr[mid], target) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func lowerBoundFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	low, high := 0, len(arr)
+	
+	for low < high {
+		mid := low + (high-low)/2
+		if compare(arr[mid], target) < 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func UpperBound(arr []int, target int) int {
+	return UpperBoundOrdered(arr, target)
+}
+
+func UpperBoundOrdered[T cmp.Ordered](arr []T, target T) int {
+	return upperBound(arr, target)
+}
+
+func UpperBoundFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return upperBoundFunc(arr, target, compare)
+}
+
+func upperBound[T cmp.Ordered](arr []T, target T) int {
+	low, high := 0, len(arr)
+	
+	for low < high {
+		mid := low + (high-low)/2
+		if !cmp.Less(target, arr[mid]) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func upperBoundFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	low, high := 0, len(arr)
+	
+	for low < high {
+		mid := low + (high-low)/2
+		if compare(arr[mid], target) <= 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+func EqualRange(arr []int, target int) (int, int) {
+	return EqualRangeOrdered(arr, target)
+}
+
+func EqualRangeOrdered[T cmp.Ordered](arr []T, target T) (int, int) {
+	first := lowerBound(arr, target)
+	last := first + upperBound(arr[first:], target)
+	return first, last
+}
+
+func EqualRangeFunc[T any](arr []T, target T, compare func(a, b T) int) (int, int) {
+	first := lowerBoundFunc(arr, target, compare)
+	last := first + upperBoundFunc(arr[first:], target, compare)
+	return first, last
+}
@@ -0,0 +1,134 @@
+package algorithms
+
+type Counter struct {
+	Comparisons uint64 `json:"comparisons"`
+	Swaps       uint64 `json:"swaps"`
+	Reads       uint64 `json:"reads"`
+	Writes      uint64 `json:"writes"`
+	
+	trace *Trace
+}
+
+type CountedSearchFunc func(arr []int, target int, counter *Counter) int
+
+type CountedSortFunc func(arr []int, counter *Counter) []int
+
+type CountedSelectFunc func(arr []int, k int, counter *Counter) (int, bool)
+
+func (c *Counter) Reset() {
+	*c = Counter{trace: c.trace}
+}
+
+func (c *Counter) Add(other Counter) {
+	c.Comparisons += other.Comparisons
+	c.Swaps += other.Swaps
+	c.Reads += other.Reads
+	c.Writes += other.Writes
+}
+
+func (c *Counter) compared(n int) {
+	if c != nil {
+		c.Comparisons += uint64(n)
+	}
+}
+
+func (c *Counter) read(n int) {
+	if c != nil {
+		c.Reads += uint64(n)
+	}
+}
+
+func (c *Counter) wrote(n int) {
+	if c != nil {
+		c.Writes += uint64(n)
+	}
+}
+
+func (c *Counter) swapped() {
+	if c != nil {
+		c.Swaps++
+		c.Reads += 2
+		c.Writes += 2
+	}
+}
+
+type ops[T any] struct {
+	compare   func(a, b T) int
+	counter   *Counter
+	interrupt *Interrupt
+}
+
+func newOps[T any](compare func(a, b T) int, counter *Counter) ops[T] {
+	if counter != nil {
+		inner := compare
+		compare = func(a, b T) int {
+			counter.Comparisons++
+			return inner(a, b)
+		}
+	}
+	return ops[T]{compare: compare, counter: counter}
+}
+
+func sortOps[T any](compare func(a, b T) int, options SortOptions) ops[T] {
+	o := newOps(compare, options.Counter)
+	o.interrupt = options.Interrupt
+	return o
+}
+
+func countedCompare[T any](compare func(a, b T) int, counter *Counter) func(a, b T) int {
+	return func(a, b T) int {
+		counter.read(1)
+		counter.compared(1)
+		return compare(a, b)
+	}
+}
+
+func (o ops[T]) at(arr array[T], i int) T {
+	o.counter.read(1)
+	return arr.values[i]
+}
+
+func (o ops[T]) set(arr array[T], i int, v T) {
+	o.counter.wrote(1)
+	arr.values[i] = v
+	o.traceWrite(arr, i)
+}
+
+func (o ops[T]) swap(arr array[T], i, j int) {
+	o.counter.swapped()
+	arr.values[i], arr.values[j] = arr.values[j], arr.values[i]
+	o.traceSwap(arr, i, j)
+}
+
+func (o ops[T]) compareAt(arr array[T], i, j int) int {
+	o.counter.read(2)
+	o.traceCompare(arr, i, j)
+	return o.compare(arr.values[i], arr.values[j])
+}
+
+func (o ops[T]) compareTo(arr array[T], i int, v T) int {
+	o.counter.read(1)
+	o.traceCompare(arr, i, -1)
+	return o.compare(arr.values[i], v)
+}
+
+func (o ops[T]) compareValue(v T, arr array[T], i int) int {
+	o.counter.read(1)
+	o.traceCompare(arr, i, -1)
+	return o.compare(v, arr.values[i])
+}
+
+func (o ops[T]) move(dst array[T], i int, src array[T], j int) {
+	o.counter.read(1)
+	o.counter.wrote(1)
+	dst.values[i] = src.values[j]
+	o.traceWrite(dst, i)
+}
+
+func (o ops[T]) copy(dst, src array[T]) int {
+	n := copy(dst.values, src.values)
+	o.counter.read(n)
+	o.counter.wrote(n)
+	o.traceWrites(dst.slice(0, n))
+	return n
+}
@@ -0,0 +1,939 @@
+package algorithms
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+const (
+	introSortThreshold = 16
+	
+	pdqMaxInsertion     = 12
+	pdqShortestNinther  = 50
+	pdqMaxSwaps         = 4 * 3
+	pdqMaxPartialSteps  = 5
+	pdqShortestShifting = 50
+	
+	timSortMinMerge  = 32
+	timSortMinGallop = 7
+)
+
+type sortedHint int
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+func init() {
+	Register(Algorithm{
+		Name:        "intro_sort",
+		DisplayName: "Introsort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			InPlace:     true,
+			BestCase:    "O(n log n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(log n)",
+		},
+		Sort:        IntroSort,
+		CountedSort: IntroSortCounted,
+		SortCompare: IntroSortFunc[int],
+		Elements:    interruptibleElementSorts(introSort[int32], introSort[int64], introSort[float64], introSort[string], introSort[Record]),
+		
+		Interruptible: interruptibleSort(introSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "pdq_sort",
+		DisplayName: "Pattern-Defeating Quicksort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			InPlace:     true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(log n)",
+		},
+		Sort:        PdqSort,
+		CountedSort: PdqSortCounted,
+		SortCompare: PdqSortFunc[int],
+		Elements:    interruptibleElementSorts(pdqSort[int32], pdqSort[int64], pdqSort[float64], pdqSort[string], pdqSort[Record]),
+		
+		Interruptible: interruptibleSort(pdqSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "tim_sort",
+		DisplayName: "TimSort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(n)",
+		},
+		Sort:        TimSort,
+		CountedSort: TimSortCounted,
+		SortCompare: TimSortFunc[int],
+		Elements:    interruptibleElementSorts(timSort[int32], timSort[int64], timSort[float64], timSort[string], timSort[Record]),
+		
+		Interruptible: interruptibleSort(timSort[int]),
+	})
+}
+
+func IntroSort(arr []int) []int {
+	return IntroSortOrdered(arr)
+}
+
+func IntroSortOrdered[T cmp.Ordered](arr []T) []T {
+	return introSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func IntroSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return introSort(arr, newOps(compare, nil))
+}
+
+func IntroSortCounted(arr []int, counter *Counter) []int {
+	return introSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func introSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
+	
+	if result.len() > 1 {
+		introSortHelper(result, 0, result.len(), 2*bits.Len(uint(result.len())), o)
+	}
+	
+	return result.values
+}
+
+func introSortHelper[T any](arr array[T], low, high, depthLimit int, o ops[T]) {
+	o.enter(arr, low, high)
+	defer o.exit(arr, low, high)
+	
+	for high-low > introSortThreshold {
+		o.checkpoint()
+		if depthLimit == 0 {
+			heapSortRange(arr, low, high, o)
+			return
+		}
+		depthLimit--
+		
+		p := medianOfThreePartition(arr, low, high, o)
+		if p-low < high-p-1 {
+			introSortHelper(arr, low, p, depthLimit, o)
+			low = p + 1
+		} else {
+			introSortHelper(arr, p+1, high, depthLimit, o)
+			high = p
+		}
+	}
+	insertionSortInPlace(arr.slice(low, high), o)
+}
+
+func medianOfThreePartition[T any](arr array[T], low, high int, o ops[T]) int {
+	mid := low + (high-low)/2
+	last := high - 1
+	
+	if o.compareAt(arr, mid, low) < 0 {
+		o.swap(arr, mid, low)
+	}
+	if o.compareAt(arr, last, low) < 0 {
+		o.swap(arr, last, low)
+	}
+	if o.compareAt(arr, last, mid) < 0 {
+		o.swap(arr, last, mid)
+	}
+	
+	o.swap(arr, mid, last-1)
+	pivot := o.at(arr, last-1)
+	
+	i, j := low, last-1
+	for {
+		for i++; o.compareTo(arr, i, pivot) < 0; i++ {
+		}
+		for j--; o.compareTo(arr, j, pivot) > 0; j-- {
+		}
+		if i >= j {
+			break
+		}
+		o.swap(arr, i, j)
+	}
+	
+	o.swap(arr, i, last-1)
+	return i
+}
+
+func heapSortRange[T any](arr array[T], low, high int, o ops[T]) {
+	sub := arr.slice(low, high)
+	n := sub.len()
+	
+	for i := n/2 - 1; i >= 0; i-- {
+		heapify(sub, n, i, o)
+	}
+	
+	for i := n - 1; i > 0; i-- {
+		o.swap(sub, 0, i)
+		heapify(sub, i, 0, o)
+	}
+}
+
+func PdqSort(arr []int) []int {
+	return PdqSortOrdered(arr)
+}
+
+func PdqSortOrdered[T cmp.Ordered](arr []T) []T {
+	return pdqSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func PdqSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return pdqSort(arr, newOps(compare, nil))
+}
+
+func PdqSortCounted(arr []int, counter *Counter) []int {
+	return pdqSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func pdqSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
+	
+	if result.len() > 1 {
+		pdqSortHelper(result, 0, result.len(), bits.Len(uint(result.len())), o)
+	}
+	
+	return result.values
+}
+
+func pdqSortHelper[T any](arr array[T], a, b, limit int, o ops[T]) {
+	o.enter(arr, a, b)
+	defer o.exit(arr, a, b)
+	
+	wasBalanced := true
+	wasPartitioned := true
+	
+	for {
+		o.checkpoint()
+		length := b - a
+		
+		if length <= pdqMaxInsertion {
+			insertionSortInPlace(arr.slice(a, b), o)
+			return
+		}
+		
+		if limit == 0 {
+			heapSortRange(arr, a, b, o)
+			return
+		}
+		
+		if !wasBalanced {
+			breakPatterns(arr, a, b, o)
+			limit--
+		}
+		
+		pivot, hint := choosePivot(arr, a, b, o)
+		if hint == decreasingHint {
+			reverseRange(arr, a, b, o)
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+		
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSort(arr, a, b, o) {
+				return
+			}
+		}
+		
+		if a > 0 && o.compareAt(arr, a-1, pivot) >= 0 {
+			a = partitionEqual(arr, a, b, pivot, o)
+			continue
+		}
+		
+		mid, alreadyPartitioned := pdqPartition(arr, a, b, pivot, o)
+		wasPartitioned = alreadyPartitioned
+		
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqSortHelper(arr, a, mid, limit, o)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqSortHelper(arr, mid+1, b, limit, o)
+			b = mid
+		}
+	}
+}
+
+func pdqPartition[T any](arr array[T], a, b, pivot int, o ops[T]) (int, bool) {
+	o.swap(arr, a, pivot)
+	i, j := a+1, b-1
+	
+	for i <= j && o.compareAt(arr, i, a) < 0 {
+		i++
+	}
+	for i <= j && o.compareAt(arr, j, a) >= 0 {
+		j--
+	}
+	if i > j {
+		o.swap(arr, j, a)
+		return j, true
+	}
+	
+	o.swap(arr, i, j)
+	i++
+	j--
+	
+	for {
+		for i <= j && o.compareAt(arr, i, a) < 0 {
+			i++
+		}
+		for i <= j && o.compareAt(arr, j, a) >= 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		o.swap(arr, i, j)
+		i++
+		j--
+	}
+	
+	o.swap(arr, j, a)
+	return j, false
+}
+
+func partitionEqual[T any](arr array[T], a, b, pivot int, o ops[T]) int {
+	o.swap(arr, a, pivot)
+	i, j := a+1, b-1
+	
+	for {
+		for i <= j && o.compareAt(arr, a, i) >= 0 {
+			i++
+		}
+		for i <= j && o.compareAt(arr, a, j) < 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		o.swap(arr, i, j)
+		i++
+		j--
+	}
+	
+	return i
+}
+
+func partialInsertionSort[T any](arr array[T], a, b int, o ops[T]) bool {
+	i := a + 1
+	for step := 0; step < pdqMaxPartialSteps; step++ {
+		for i < b && o.compareAt(arr, i, i-1) >= 0 {
+			i++
+		}
+		
+		if i == b {
+			return true
+		}
+		
+		if b-a < pdqShortestShifting {
+			return false
+		}
+		
+		o.swap(arr, i, i-1)
+		
+		for j := i - 1; j > a; j-- {
+			if o.compareAt(arr, j, j-1) >= 0 {
+				break
+			}
+			o.swap(arr, j, j-1)
+		}
+		
+		for j := i + 1; j < b; j++ {
+			if o.compareAt(arr, j, j-1) >= 0 {
+				break
+			}
+			o.swap(arr, j, j-1)
+		}
+	}
+	return false
+}
+
+func breakPatterns[T any](arr array[T], a, b int, o ops[T]) {
+	length := b - a
+	if length < 8 {
+		return
+	}
+	
+	random := uint64(length)
+	modulus := uint(1) << bits.Len(uint(length))
+	
+	idx := a + (length/4)*2 - 1
+	for i := 0; i < 3; i++ {
+		random ^= random << 13
+		random ^= random >> 7
+		random ^= random << 17
+		
+		other := int(uint(random) & (modulus - 1))
+		if other >= length {
+			other -= length
+		}
+		o.swap(arr, idx-1+i, a+other)
+	}
+}
+
+func choosePivot[T any](arr array[T], a, b int, o ops[T]) (int, sortedHint) {
+	l := b - a
+	swaps := 0
+	i := a + l/4*1
+	j := a + l/4*2
+	k := a + l/4*3
+	
+	if l >= 8 {
+		if l >= pdqShortestNinther {
+			i = medianAdjacent(arr, i, &swaps, o)
+			j = medianAdjacent(arr, j, &swaps, o)
+			k = medianAdjacent(arr, k, &swaps, o)
+		}
+		j = medianIndex(arr, i, j, k, &swaps, o)
+	}
+	
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case pdqMaxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+func order2[T any](arr array[T], a, b int, swaps *int, o ops[T]) (int, int) {
+	if o.compareAt(arr, b, a) < 0 {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+func medianIndex[T any](arr array[T], a, b, c int, swaps *int, o ops[T]) int {
+	a, b = order2(arr, a, b, swaps, o)
+	b, c = order2(arr, b, c, swaps, o)
+	a, b = order2(arr, a, b, swaps, o)
+	return b
+}
+
+func medianAdjacent[T any](arr array[T], a int, swaps *int, o ops[T]) int {
+	return medianIndex(arr, a-1, a, a+1, swaps, o)
+}
+
+func reverseRange[T any](arr array[T], a, b int, o ops[T]) {
+	for i, j := a, b-1; i < j; i, j = i+1, j-1 {
+		o.swap(arr, i, j)
+	}
+}
+
+type timSortState[T any] struct {
+	arr       array[T]
+	tmp       []T
+	o         ops[T]
+	minGallop int
+	runBase   []int
+	runLen    []int
+}
+
+func TimSort(arr []int) []int {
+	return TimSortOrdered(arr)
+}
+
+func TimSortOrdered[T cmp.Ordered](arr []T) []T {
+	return timSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func TimSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return timSort(arr, newOps(compare, nil))
+}
+
+func TimSortCounted(arr []int, counter *Counter) []int {
+	return timSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func timSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
+	
+	n := result.len()
+	if n < 2 {
+		return result.values
+	}
+	
+	if n < timSortMinMerge {
+		initRunLen := countRunAndMakeAscending(result, 0, n, o)
+		binaryInsertionSort(result, 0, n, initRunLen, o)
+		return result.values
+	}
+	
+	ts := &timSortState[T]{arr: result, o: o, minGallop: timSortMinGallop}
+	minRun := minRunLength(n)
+	
+	lo, remaining := 0, n
+	for remaining != 0 {
+		o.checkpoint()
+		runLen := countRunAndMakeAscending(result, lo, lo+remaining, o)
+		
+		if runLen < minRun {
+			force := minRun
+			if remaining < force {
+				force = remaining
+			}
+			binaryInsertionSort(result, lo, lo+force, lo+runLen, o)
+			runLen = force
+		}
+		
+		ts.runBase = append(ts.runBase, lo)
+		ts.runLen = append(ts.runLen, runLen)
+		ts.mergeCollapse()
+		
+		lo += runLen
+		remaining -= runLen
+	}
+	
+	ts.mergeForceCollapse()
+	return result.values
+}
+
+func minRunLength(n int) int {
+	r := 0
+	for n >= timSortMinMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+func countRunAndMakeAscending[T any](arr array[T], lo, hi int, o ops[T]) int {
+	runHi := lo + 1
+	if runHi == hi {
+		return 1
+	}
+	
+	if o.compareAt(arr, runHi, lo) < 0 {
+		runHi++
+		for runHi < hi && o.compareAt(arr, runHi, runHi-1) < 0 {
+			runHi++
+		}
+		reverseRange(arr, lo, runHi, o)
+	} else {
+		runHi++
+		for runHi < hi && o.compareAt(arr, runHi, runHi-1) >= 0 {
+			runHi++
+		}
+	}
+	
+	return runHi - lo
+}
+
+func binaryInsertionSort[T any](arr array[T], lo, hi, start int, o ops[T]) {
+	if start == lo {
+		start++
+	}
+	
+	for ; start < hi; start++ {
+		pivot := o.at(arr, start)
+		
+		left, right := lo, start
+		for left < right {
+			mid := int(uint(left+right) >> 1)
+			if o.compareValue(pivot, arr, mid) < 0 {
+				right = mid
+			} else {
+				left = mid + 1
+			}
+		}
+		
+		o.copy(arr.slice(left+1, start+1), arr.slice(left, start))
+		o.set(arr, left, pivot)
+	}
+}
+
+func (ts *timSortState[T]) mergeCollapse() {
+	for len(ts.runLen) > 1 {
+		n := len(ts.runLen) - 2
+		if (n > 0 && ts.runLen[n-1] <= ts.runLen[n]+ts.runLen[n+1]) ||
+			(n > 1 && ts.runLen[n-2] <= ts.runLen[n-1]+ts.runLen[n]) {
+			if ts.runLen[n-1] < ts.runLen[n+1] {
+				n--
+			}
+		} else if ts.runLen[n] > ts.runLen[n+1] {
+			break
+		}
+		ts.mergeAt(n)
+	}
+}
+
+func (ts *timSortState[T]) mergeForceCollapse() {
+	for len(ts.runLen) > 1 {
+		n := len(ts.runLen) - 2
+		if n > 0 && ts.runLen[n-1] < ts.runLen[n+1] {
+			n--
+		}
+		ts.mergeAt(n)
+	}
+}
+
+func (ts *timSortState[T]) mergeAt(i int) {
+	arr := ts.arr
+	o := ts.o
+	base1, len1 := ts.runBase[i], ts.runLen[i]
+	base2, len2 := ts.runBase[i+1], ts.runLen[i+1]
+	o.enter(arr, base1, base2+len2)
+	defer o.exit(arr, base1, base2+len2)
+	
+	ts.runLen[i] = len1 + len2
+	if i == len(ts.runLen)-3 {
+		ts.runBase[i+1] = ts.runBase[i+2]
+		ts.runLen[i+1] = ts.runLen[i+2]
+	}
+	ts.runBase = ts.runBase[:len(ts.runBase)-1]
+	ts.runLen = ts.runLen[:len(ts.runLen)-1]
+	
+	k := gallopRight(o.at(arr, base2), arr, base1, len1, 0, o)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+	
+	len2 = gallopLeft(o.at(arr, base1+len1-1), arr, base2, len2, len2-1, o)
+	if len2 == 0 {
+		return
+	}
+	
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+func gallopLeft[T any](key T, arr array[T], base, length, hint int, o ops[T]) int {
+	lastOfs, ofs := 0, 1
+	
+	if o.compareValue(key, arr, base+hint) > 0 {
+		maxOfs := length - hint
+		for ofs < maxOfs && o.compareValue(key, arr, base+hint+ofs) > 0 {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs += hint
+		ofs += hint
+	} else {
+		maxOfs := hint + 1
+		for ofs < maxOfs && o.compareValue(key, arr, base+hint-ofs) <= 0 {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	}
+	
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)>>1
+		if o.compareValue(key, arr, base+m) > 0 {
+			lastOfs = m + 1
+		} else {
+			ofs = m
+		}
+	}
+	return ofs
+}
+
+func gallopRight[T any](key T, arr array[T], base, length, hint int, o ops[T]) int {
+	lastOfs, ofs := 0, 1
+	
+	if o.compareValue(key, arr, base+hint) < 0 {
+		maxOfs := hint + 1
+		for ofs < maxOfs && o.compareValue(key, arr, base+hint-ofs) < 0 {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else {
+		maxOfs := length - hint
+		for ofs < maxOfs && o.compareValue(key, arr, base+hint+ofs) >= 0 {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs += hint
+		ofs += hint
+	}
+	
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)>>1
+		if o.compareValue(key, arr, base+m) < 0 {
+			ofs = m
+		} else {
+			lastOfs = m + 1
+		}
+	}
+	return ofs
+}
+
+func (ts *timSortState[T]) ensureCapacity(n int) array[T] {
+	if cap(ts.tmp) < n {
+		ts.tmp = make([]T, n)
+	}
+	return scratch(ts.tmp[:n])
+}
+
+func (ts *timSortState[T]) mergeLo(base1, len1, base2, len2 int) {
+	arr := ts.arr
+	o := ts.o
+	tmp := ts.ensureCapacity(len1)
+	o.copy(tmp, arr.slice(base1, base1+len1))
+	
+	cursor1, cursor2, dest := 0, base2, base1
+	
+	o.move(arr, dest, arr, cursor2)
+	dest++
+	cursor2++
+	len2--
+	if len2 == 0 {
+		o.copy(arr.slice(dest, dest+len1), tmp.slice(cursor1, cursor1+len1))
+		return
+	}
+	if len1 == 1 {
+		o.copy(arr.slice(dest, dest+len2), arr.slice(cursor2, cursor2+len2))
+		o.move(arr, dest+len2, tmp, cursor1)
+		return
+	}
+	
+	minGallop := ts.minGallop
+
+outer:
+	for {
+		count1, count2 := 0, 0
+		
+		for {
+			if o.compare(o.at(arr, cursor2), o.at(tmp, cursor1)) < 0 {
+				o.move(arr, dest, arr, cursor2)
+				dest++
+				cursor2++
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 0 {
+					break outer
+				}
+			} else {
+				o.move(arr, dest, tmp, cursor1)
+				dest++
+				cursor1++
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 1 {
+					break outer
+				}
+			}
+			if (count1 | count2) >= minGallop {
+				break
+			}
+		}
+		
+		for {
+			count1 = gallopRight(o.at(arr, cursor2), tmp, cursor1, len1, 0, o)
+			if count1 != 0 {
+				o.copy(arr.slice(dest, dest+count1), tmp.slice(cursor1, cursor1+count1))
+				dest += count1
+				cursor1 += count1
+				len1 -= count1
+				if len1 <= 1 {
+					break outer
+				}
+			}
+			o.move(arr, dest, arr, cursor2)
+			dest++
+			cursor2++
+			len2--
+			if len2 == 0 {
+				break outer
+			}
+			
+			count2 = gallopLeft(o.at(tmp, cursor1), arr, cursor2, len2, 0, o)
+			if count2 != 0 {
+				o.copy(arr.slice(dest, dest+count2), arr.slice(cursor2, cursor2+count2))
+				dest += count2
+				cursor2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+			o.move(arr, dest, tmp, cursor1)
+			dest++
+			cursor1++
+			len1--
+			if len1 == 1 {
+				break outer
+			}
+			
+			minGallop--
+			if count1 < timSortMinGallop && count2 < timSortMinGallop {
+				break
+			}
+		}
+		
+		if minGallop < 0 {
+			minGallop = 0
+		}
+		minGallop += 2
+	}
+	
+	if minGallop < 1 {
+		minGallop = 1
+	}
+	ts.minGallop = minGallop
+	
+	if len1 == 1 {
+		o.copy(arr.slice(dest, dest+len2), arr.slice(cursor2, cursor2+len2))
+		o.move(arr, dest+len2, tmp, cursor1)
+	} else {
+		o.copy(arr.slice(dest, dest+len1), tmp.slice(cursor1, cursor1+len1))
+	}
+}
+
+func (ts *timSortState[T]) mergeHi(base1, len1, base2, len2 int) {
+	arr := ts.arr
+	o := ts.o
+	tmp := ts.ensureCapacity(len2)
+	o.copy(tmp, arr.slice(base2, base2+len2))
+	
+	cursor1, cursor2, dest := base1+len1-1, len2-1, base2+len2-1
+	
+	o.move(arr, dest, arr, cursor1)
+	dest--
+	cursor1--
+	len1--
+	if len1 == 0 {
+		o.copy(arr.slice(dest-(len2-1), dest+1), tmp.slice(0, len2))
+		return
+	}
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		o.copy(arr.slice(dest+1, dest+1+len1), arr.slice(cursor1+1, cursor1+1+len1))
+		o.move(arr, dest, tmp, cursor2)
+		return
+	}
+	
+	minGallop := ts.minGallop
+
+outer:
+	for {
+		count1, count2 := 0, 0
+		
+		for {
+			if o.compare(o.at(tmp, cursor2), o.at(arr, cursor1)) < 0 {
+				o.move(arr, dest, arr, cursor1)
+				dest--
+				cursor1--
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 0 {
+					break outer
+				}
+			} else {
+				o.move(arr, dest, tmp, cursor2)
+				dest--
+				cursor2--
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 1 {
+					break outer
+				}
+			}
+			if (count1 | count2) >= minGallop {
+				break
+			}
+		}
+		
+		for {
+			count1 = len1 - gallopRight(o.at(tmp, cursor2), arr, base1, len1, len1-1, o)
+			if count1 != 0 {
+				dest -= count1
+				cursor1 -= count1
+				len1 -= count1
+				o.copy(arr.slice(dest+1, dest+1+count1), arr.slice(cursor1+1, cursor1+1+count1))
+				if len1 == 0 {
+					break outer
+				}
+			}
+			o.move(arr, dest, tmp, cursor2)
+			dest--
+			cursor2--
+			len2--
+			if len2 == 1 {
+				break outer
+			}
+			
+			count2 = len2 - gallopLeft(o.at(arr, cursor1), tmp, 0, len2, len2-1, o)
+			if count2 != 0 {
+				dest -= count2
+				cursor2 -= count2
+				len2 -= count2
+				o.copy(arr.slice(dest+1, dest+1+count2), tmp.slice(cursor2+1, cursor2+1+count2))
+				if len2 <= 1 {
+					break outer
+				}
+			}
+			o.move(arr, dest, arr, cursor1)
+			dest--
+			cursor1--
+			len1--
+			if len1 == 0 {
+				break outer
+			}
+			
+			minGallop--
+			if count1 < timSortMinGallop && count2 < timSortMinGallop {
+				break
+			}
+		}
+		
+		if minGallop < 0 {
+			minGallop = 0
+		}
+		minGallop += 2
+	}
+	
+	if minGallop < 1 {
+		minGallop = 1
+	}
+	ts.minGallop = minGallop
+	
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		o.copy(arr.slice(dest+1, dest+1+len1), arr.slice(cursor1+1, cursor1+1+len1))
+		o.move(arr, dest, tmp, cursor2)
+	} else {
+		o.copy(arr.slice(dest-(len2-1), dest+1), tmp.slice(0, len2))
+	}
+}
@@ -0,0 +1,381 @@
+package algorithms
+
+import "cmp"
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+const (
+	DefaultRadixBits = 8
+	DefaultMSDCutoff = 32
+	
+	CountingSortRangeFactor = 4
+	CountingSortMinRange    = 1 << 16
+)
+
+func init() {
+	Register(Algorithm{
+		Name:        "radix_sort_lsd",
+		DisplayName: "Radix Sort (LSD)",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			BestCase:    "O(w/b · (n + 2^b))",
+			AverageCase: "O(w/b · (n + 2^b))",
+			WorstCase:   "O(w/b · (n + 2^b))",
+			Space:       "O(n + 2^b)",
+		},
+		Sort:        RadixSortLSD,
+		CountedSort: RadixSortLSDCounted,
+		Elements:    IntegerElementSorts(radixSortLSDDefault, radixSortLSDDefault),
+	})
+	Register(Algorithm{
+		Name:        "radix_sort_msd",
+		DisplayName: "Radix Sort (MSD)",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			BestCase:    "O(n)",
+			AverageCase: "O(w/b · (n + 2^b))",
+			WorstCase:   "O(w/b · (n + 2^b))",
+			Space:       "O(n + w/b · 2^b)",
+		},
+		Sort:        RadixSortMSD,
+		CountedSort: RadixSortMSDCounted,
+		Elements:    IntegerElementSorts(radixSortMSDDefault, radixSortMSDDefault),
+	})
+	Register(Algorithm{
+		Name:        "counting_sort",
+		DisplayName: "Counting Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			BestCase:    "O(n + k)",
+			AverageCase: "O(n + k)",
+			WorstCase:   "O(n + k)",
+			Space:       "O(n + k)",
+		},
+		Sort:        CountingSort,
+		CountedSort: CountingSortCounted,
+		Elements:    IntegerElementSorts(CountingSortInteger, CountingSortInteger),
+	})
+	Register(Algorithm{
+		Name:        "bucket_sort",
+		DisplayName: "Bucket Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n)",
+			WorstCase:   "O(n²)",
+			Space:       "O(n)",
+		},
+		Sort:        BucketSort,
+		CountedSort: BucketSortCounted,
+		Elements:    IntegerElementSorts(BucketSortInteger, BucketSortInteger),
+	})
+}
+
+func RadixSortLSD(arr []int) []int {
+	return RadixSortLSDWithWidth(arr, DefaultRadixBits)
+}
+
+func RadixSortLSDWithWidth(arr []int, bits int) []int {
+	return RadixSortLSDInteger(arr, bits)
+}
+
+func RadixSortLSDInteger[T Integer](arr []T, bits int) []T {
+	return radixSortLSD(arr, bits, nil)
+}
+
+func radixSortLSDDefault[T Integer](arr []T) []T {
+	return RadixSortLSDInteger(arr, DefaultRadixBits)
+}
+
+func RadixSortLSDCounted(arr []int, counter *Counter) []int {
+	return radixSortLSD(arr, DefaultRadixBits, counter)
+}
+
+func radixSortLSD[T Integer](arr []T, bits int, counter *Counter) []T {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	
+	if len(result) <= 1 {
+		return result
+	}
+	if bits < 1 {
+		bits = 1
+	} else if bits > 16 {
+		bits = 16
+	}
+	
+	min, max := minMax(result, counter)
+	maxKey := radixKey(max, min)
+	
+	buckets := 1 << bits
+	mask := uint64(buckets - 1)
+	count := make([]int, buckets)
+	
+	o := newOps(cmp.Compare[T], counter)
+	current := o.track(result)
+	buffer := o.track(make([]T, len(result)))
+	
+	for shift := uint(0); shift < 64 && maxKey>>shift > 0; shift += uint(bits) {
+		for i := range count {
+			count[i] = 0
+		}
+		for _, v := range current.values {
+			count[(radixKey(v, min)>>shift)&mask]++
+		}
+		counter.read(len(result))
+		
+		total := 0
+		for i := range count {
+			count[i], total = total, total+count[i]
+		}
+		
+		for _, v := range current.values {
+			digit := (radixKey(v, min) >> shift) & mask
+			buffer.values[count[digit]] = v
+			o.traceWrite(buffer, count[digit])
+			count[digit]++
+		}
+		counter.read(len(result))
+		counter.wrote(len(result))
+		
+		current, buffer = buffer, current
+	}
+	
+	return current.values
+}
+
+func RadixSortMSD(arr []int) []int {
+	return RadixSortMSDWithCutoff(arr, DefaultMSDCutoff)
+}
+
+func RadixSortMSDWithCutoff(arr []int, cutoff int) []int {
+	return RadixSortMSDInteger(arr, cutoff)
+}
+
+func RadixSortMSDInteger[T Integer](arr []T, cutoff int) []T {
+	return radixSortMSD(arr, cutoff, nil)
+}
+
+func radixSortMSDDefault[T Integer](arr []T) []T {
+	return RadixSortMSDInteger(arr, DefaultMSDCutoff)
+}
+
+func RadixSortMSDCounted(arr []int, counter *Counter) []int {
+	return radixSortMSD(arr, DefaultMSDCutoff, counter)
+}
+
+func radixSortMSD[T Integer](arr []T, cutoff int, counter *Counter) []T {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	
+	if len(result) <= 1 {
+		return result
+	}
+	if cutoff < 1 {
+		cutoff = 1
+	}
+	
+	min, max := minMax(result, counter)
+	maxKey := radixKey(max, min)
+	
+	shift := uint(0)
+	for shift+DefaultRadixBits < 64 && maxKey>>(shift+DefaultRadixBits) > 0 {
+		shift += DefaultRadixBits
+	}
+	
+	o := newOps(cmp.Compare[T], counter)
+	msdSortHelper(o.track(result), o.track(make([]T, len(result))), min, shift, cutoff, o)
+	
+	return result
+}
+
+func msdSortHelper[T Integer](arr, buffer array[T], min T, shift uint, cutoff int, o ops[T]) {
+	o.enter(arr, 0, arr.len())
+	defer o.exit(arr, 0, arr.len())
+	
+	if arr.len() <= cutoff {
+		insertionSortInPlace(arr, o)
+		return
+	}
+	
+	const buckets = 1 << DefaultRadixBits
+	const mask = buckets - 1
+	var count [buckets + 1]int
+	
+	for _, v := range arr.values {
+		count[(radixKey(v, min)>>shift)&mask+1]++
+	}
+	o.counter.read(arr.len())
+	for i := 1; i <= buckets; i++ {
+		count[i] += count[i-1]
+	}
+	
+	var next [buckets]int
+	copy(next[:], count[:buckets])
+	
+	for _, v := range arr.values {
+		digit := (radixKey(v, min) >> shift) & mask
+		buffer.values[next[digit]] = v
+		o.traceWrite(buffer, next[digit])
+		next[digit]++
+	}
+	o.counter.read(arr.len())
+	o.counter.wrote(arr.len())
+	o.copy(arr, buffer.slice(0, arr.len()))
+	
+	if shift == 0 {
+		return
+	}
+	
+	for i := 0; i < buckets; i++ {
+		low, high := count[i], count[i+1]
+		if high-low > 1 {
+			msdSortHelper(arr.slice(low, high), buffer.slice(low, high), min, shift-DefaultRadixBits, cutoff, o)
+		}
+	}
+}
+
+func CountingSort(arr []int) []int {
+	return CountingSortInteger(arr)
+}
+
+func CountingSortInteger[T Integer](arr []T) []T {
+	return countingSort(arr, nil)
+}
+
+func CountingSortCounted(arr []int, counter *Counter) []int {
+	return countingSort(arr, counter)
+}
+
+func countingSort[T Integer](arr []T, counter *Counter) []T {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	
+	if len(result) <= 1 {
+		return result
+	}
+	
+	min, max := minMax(result, counter)
+	if !countingRangeFits(radixKey(max, min), len(arr)) {
+		return radixSortLSD(arr, DefaultRadixBits, counter)
+	}
+	count := make([]int, radixKey(max, min)+1)
+	
+	o := newOps(cmp.Compare[T], counter)
+	sorted := o.track(result)
+	
+	for _, v := range arr {
+		count[radixKey(v, min)]++
+	}
+	counter.read(len(arr))
+	
+	total := 0
+	for i := range count {
+		count[i], total = total, total+count[i]
+	}
+	
+	for _, v := range arr {
+		key := radixKey(v, min)
+		sorted.values[count[key]] = v
+		o.traceWrite(sorted, count[key])
+		count[key]++
+	}
+	counter.read(len(arr))
+	counter.wrote(len(arr))
+	
+	return result
+}
+
+func BucketSort(arr []int) []int {
+	return BucketSortInteger(arr)
+}
+
+func BucketSortInteger[T Integer](arr []T) []T {
+	return bucketSort(arr, nil)
+}
+
+func BucketSortCounted(arr []int, counter *Counter) []int {
+	return bucketSort(arr, counter)
+}
+
+func bucketSort[T Integer](arr []T, counter *Counter) []T {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	
+	n := len(result)
+	if n <= 1 {
+		return result
+	}
+	
+	min, max := minMax(result, counter)
+	span := float64(radixKey(max, min)) + 1
+	
+	o := newOps(cmp.Compare[T], counter)
+	sorted := o.track(result)
+	
+	buckets := make([][]T, n)
+	for _, v := range arr {
+		index := int(float64(radixKey(v, min)) / span * float64(n))
+		if index >= n {
+			index = n - 1
+		}
+		buckets[index] = append(buckets[index], v)
+	}
+	counter.read(n)
+	counter.wrote(n)
+	
+	pos := 0
+	for _, bucket := range buckets {
+		insertionSortInPlace(scratch(bucket), o)
+		pos += o.copy(sorted.slice(pos, n), scratch(bucket))
+	}
+	
+	return result
+}
+
+func minMax[T cmp.Ordered](arr []T, counter *Counter) (T, T) {
+	counter.read(len(arr))
+	counter.compared(2 * (len(arr) - 1))
+	
+	min, max := arr[0], arr[0]
+	for _, v := range arr[1:] {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+func countingRangeFits(span uint64, n int) bool {
+	limit := uint64(n) * CountingSortRangeFactor
+	if limit < CountingSortMinRange {
+		limit = CountingSortMinRange
+	}
+	return span < limit
+}
+
+func radixKey[T Integer](v, min T) uint64 {
+	return uint64(v) - uint64(min)
+}
+
+func insertionSortInPlace[T any](arr array[T], o ops[T]) {
+	for i := 1; i < arr.len(); i++ {
+		o.checkpoint()
+		key := o.at(arr, i)
+		j := i - 1
+		for j >= 0 && o.compareTo(arr, j, key) > 0 {
+			o.set(arr, j+1, o.at(arr, j))
+			j--
+		}
+		o.set(arr, j+1, key)
+	}
+}
@@ -0,0 +1,63 @@
+package algorithms
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var ErrInterrupted = errors.New("interrupted")
+
+type interrupted struct{}
+
+type Interrupt struct {
+	stopped atomic.Bool
+}
+
+func WatchContext(ctx context.Context) (*Interrupt, func() bool) {
+	interrupt := &Interrupt{}
+	stop := context.AfterFunc(ctx, interrupt.Stop)
+	return interrupt, stop
+}
+
+func (i *Interrupt) Stop() {
+	i.stopped.Store(true)
+}
+
+func (i *Interrupt) Stopped() bool {
+	return i != nil && i.stopped.Load()
+}
+
+func (i *Interrupt) Run(run func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(interrupted); !ok {
+				panic(r)
+			}
+			err = ErrInterrupted
+		}
+	}()
+	
+	if i.Stopped() {
+		return ErrInterrupted
+	}
+	run()
+	return nil
+}
+
+func (i *Interrupt) checkpoint() {
+	if i.Stopped() {
+		panic(interrupted{})
+	}
+}
+
+func (o ops[T]) checkpoint() {
+	o.interrupt.checkpoint()
+}
+
+func interruptibleSort(sort func(arr []int, o ops[int]) []int) SortWithOptionsFunc {
+	return func(arr []int, options SortOptions) []int {
+		return sort(arr, sortOps(cmp.Compare[int], options))
+	}
+}
@@ -0,0 +1,220 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+type PivotStrategy int
+
+const (
+	PivotLast PivotStrategy = iota
+	PivotFirst
+	PivotRandom
+	PivotMedianOfThree
+	PivotNinther
+)
+
+type PartitionScheme int
+
+const (
+	PartitionLomuto PartitionScheme = iota
+	PartitionHoare
+	PartitionThreeWay
+)
+
+const nintherThreshold = 40
+
+func QuickSortWithOptions(arr []int, options SortOptions) []int {
+	return QuickSortOrderedWithOptions(arr, options)
+}
+
+func QuickSortOrderedWithOptions[T cmp.Ordered](arr []T, options SortOptions) []T {
+	return quickSort(arr, sortOps(cmp.Compare[T], options), options)
+}
+
+func QuickSortFuncWithOptions[T any](arr []T, compare func(a, b T) int, options SortOptions) []T {
+	return quickSort(arr, sortOps(compare, options), options)
+}
+
+func quickSort[T any](arr []T, o ops[T], options SortOptions) []T {
+	result := o.input(arr)
+	
+	if result.len() > 1 {
+		quickSortRange(result, 0, result.len()-1, o, options)
+	}
+	
+	return result.values
+}
+
+func quickSortRange[T any](arr array[T], low, high int, o ops[T], options SortOptions) {
+	o.enter(arr, low, high+1)
+	defer o.exit(arr, low, high+1)
+	
+	for low < high {
+		o.checkpoint()
+		p := choosePivotIndex(arr, low, high, o, options)
+		
+		var leftHigh, rightLow int
+		switch options.Partition {
+		case PartitionHoare:
+			o.swap(arr, low, p)
+			j := hoarePartition(arr, low, high, o)
+			leftHigh, rightLow = j, j+1
+		case PartitionThreeWay:
+			lt, gt := threeWayPartition(arr, low, high, o.at(arr, p), o)
+			leftHigh, rightLow = lt-1, gt+1
+		default:
+			o.swap(arr, high, p)
+			pi := partition(arr, low, high, o)
+			leftHigh, rightLow = pi-1, pi+1
+		}
+		
+		if leftHigh-low < high-rightLow {
+			quickSortRange(arr, low, leftHigh, o, options)
+			low = rightLow
+		} else {
+			quickSortRange(arr, rightLow, high, o, options)
+			high = leftHigh
+		}
+	}
+}
+
+func choosePivotIndex[T any](arr array[T], low, high int, o ops[T], options SortOptions) int {
+	switch options.Pivot {
+	case PivotFirst:
+		return low
+	case PivotRandom:
+		if options.Rand != nil {
+			return low + options.Rand.Intn(high-low+1)
+		}
+		return low + rand.Intn(high-low+1)
+	case PivotMedianOfThree:
+		return medianOfThreeIndex(arr, low, low+(high-low)/2, high, o)
+	case PivotNinther:
+		if high-low+1 < nintherThreshold {
+			return medianOfThreeIndex(arr, low, low+(high-low)/2, high, o)
+		}
+		step := (high - low + 1) / 8
+		mid := low + (high-low)/2
+		return medianOfThreeIndex(arr,
+			medianOfThreeIndex(arr, low, low+step, low+2*step, o),
+			medianOfThreeIndex(arr, mid-step, mid, mid+step, o),
+			medianOfThreeIndex(arr, high-2*step, high-step, high, o),
+			o,
+		)
+	default:
+		return high
+	}
+}
+
+func medianOfThreeIndex[T any](arr array[T], a, b, c int, o ops[T]) int {
+	if o.compareAt(arr, a, b) < 0 {
+		if o.compareAt(arr, b, c) < 0 {
+			return b
+		} else if o.compareAt(arr, a, c) < 0 {
+			return c
+		}
+		return a
+	}
+	if o.compareAt(arr, a, c) < 0 {
+		return a
+	} else if o.compareAt(arr, b, c) < 0 {
+		return c
+	}
+	return b
+}
+
+func hoarePartition[T any](arr array[T], low, high int, o ops[T]) int {
+	pivot := o.at(arr, low)
+	i, j := low-1, high+1
+	
+	for {
+		for i++; o.compareTo(arr, i, pivot) < 0; i++ {
+		}
+		for j--; o.compareTo(arr, j, pivot) > 0; j-- {
+		}
+		if i >= j {
+			return j
+		}
+		o.swap(arr, i, j)
+	}
+}
+
+func threeWayPartition[T any](arr array[T], low, high int, pivot T, o ops[T]) (int, int) {
+	lt, i, gt := low, low, high
+	
+	for i <= gt {
+		c := o.compareTo(arr, i, pivot)
+		if c < 0 {
+			o.swap(arr, lt, i)
+			lt++
+			i++
+		} else if c > 0 {
+			o.swap(arr, i, gt)
+			gt--
+		} else {
+			i++
+		}
+	}
+	
+	return lt, gt
+}
+
+func GetPivotStrategyName(pivot PivotStrategy) string {
+	switch pivot {
+	case PivotLast:
+		return "last"
+	case PivotFirst:
+		return "first"
+	case PivotRandom:
+		return "random"
+	case PivotMedianOfThree:
+		return "median_of_three"
+	case PivotNinther:
+		return "ninther"
+	default:
+		return "unknown"
+	}
+}
+
+func GetAllPivotStrategies() []PivotStrategy {
+	return []PivotStrategy{PivotLast, PivotFirst, PivotRandom, PivotMedianOfThree, PivotNinther}
+}
+
+func ParsePivotStrategy(name string) (PivotStrategy, error) {
+	for _, pivot := range GetAllPivotStrategies() {
+		if strings.EqualFold(name, GetPivotStrategyName(pivot)) {
+			return pivot, nil
+		}
+	}
+	return PivotLast, fmt.Errorf("unknown pivot strategy: %s", name)
+}
+
+func GetPartitionSchemeName(scheme PartitionScheme) string {
+	switch scheme {
+	case PartitionLomuto:
+		return "lomuto"
+	case PartitionHoare:
+		return "hoare"
+	case PartitionThreeWay:
+		return "three_way"
+	default:
+		return "unknown"
+	}
+}
+
+func GetAllPartitionSchemes() []PartitionScheme {
+	return []PartitionScheme{PartitionLomuto, PartitionHoare, PartitionThreeWay}
+}
+
+func ParsePartitionScheme(name string) (PartitionScheme, error) {
+	for _, scheme := range GetAllPartitionSchemes() {
+		if strings.EqualFold(name, GetPartitionSchemeName(scheme)) {
+			return scheme, nil
+		}
+	}
+	return PartitionLomuto, fmt.Errorf("unknown partition scheme: %s", name)
+}
@@ -0,0 +1,313 @@
+package algorithms
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+type Kind int
+
+const (
+	Search Kind = iota
+	Sort
+	Select
+)
+
+type SearchFunc func(arr []int, target int) int
+
+type SortFunc func(arr []int) []int
+
+type SelectFunc func(arr []int, k int) (int, bool)
+
+type SortOptions struct {
+	Pivot     PivotStrategy
+	Partition PartitionScheme
+	Rand      *rand.Rand
+	Counter   *Counter
+	Interrupt *Interrupt
+}
+
+type SortWithOptionsFunc func(arr []int, options SortOptions) []int
+
+type SortCompareFunc func(arr []int, compare func(a, b int) int) []int
+
+type SortCompareWithOptionsFunc func(arr []int, compare func(a, b int) int, options SortOptions) []int
+
+type LayoutFunc func(sorted []int, counter *Counter) func(target int) int
+
+type Record struct {
+	Key     int64
+	Payload [7]int64
+}
+
+func CompareRecords(a, b Record) int {
+	return cmp.Compare(a.Key, b.Key)
+}
+
+type ElementSorts struct {
+	Int32   func(arr []int32, options SortOptions) []int32
+	Int64   func(arr []int64, options SortOptions) []int64
+	Float64 func(arr []float64, options SortOptions) []float64
+	String  func(arr []string, options SortOptions) []string
+	Record  func(arr []Record, options SortOptions) []Record
+}
+
+type Metadata struct {
+	Stable         bool
+	InPlace        bool
+	RequiresSorted bool
+	BestCase       string
+	AverageCase    string
+	WorstCase      string
+	Space          string
+}
+
+type Algorithm struct {
+	Name        string
+	DisplayName string
+	Kind        Kind
+	Metadata    Metadata
+	Search      SearchFunc
+	Sort        SortFunc
+	Select      SelectFunc
+	
+	SortWithOptions SortWithOptionsFunc
+	SortCompare     SortCompareFunc
+	Layout          LayoutFunc
+	
+	SortCompareWithOptions SortCompareWithOptionsFunc
+	Elements               ElementSorts
+	Interruptible          SortWithOptionsFunc
+	
+	CountedSearch CountedSearchFunc
+	CountedSort   CountedSortFunc
+	CountedSelect CountedSelectFunc
+}
+
+var (
+	registry      = make(map[string]Algorithm)
+	registryOrder []string
+)
+
+func Register(algorithm Algorithm) {
+	if algorithm.Name == "" {
+		panic("algorithms: Register called with empty name")
+	}
+	if _, exists := registry[algorithm.Name]; exists {
+		panic(fmt.Sprintf("algorithms: Register called twice for %s", algorithm.Name))
+	}
+	
+	switch algorithm.Kind {
+	case Search:
+		if algorithm.Search == nil && algorithm.Layout == nil {
+			panic(fmt.Sprintf("algorithms: search %s has no entry point", algorithm.Name))
+		}
+	case Sort:
+		if algorithm.Sort == nil {
+			panic(fmt.Sprintf("algorithms: sort %s has no entry point", algorithm.Name))
+		}
+	case Select:
+		if algorithm.Select == nil {
+			panic(fmt.Sprintf("algorithms: select %s has no entry point", algorithm.Name))
+		}
+	default:
+		panic(fmt.Sprintf("algorithms: unknown kind %d for %s", algorithm.Kind, algorithm.Name))
+	}
+	
+	if algorithm.DisplayName == "" {
+		algorithm.DisplayName = algorithm.Name
+	}
+	
+	registry[algorithm.Name] = algorithm
+	registryOrder = append(registryOrder, algorithm.Name)
+}
+
+func Get(name string) (Algorithm, bool) {
+	algorithm, ok := registry[name]
+	return algorithm, ok
+}
+
+func ComparisonSort(name string, options SortOptions) (SortCompareFunc, error) {
+	algorithm, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm: %s", name)
+	}
+	if algorithm.Kind != Sort {
+		return nil, fmt.Errorf("algorithm %s is not a sort", name)
+	}
+	
+	switch {
+	case algorithm.SortCompareWithOptions != nil:
+		return func(arr []int, compare func(a, b int) int) []int {
+			return algorithm.SortCompareWithOptions(arr, compare, options)
+		}, nil
+	case options.Pivot != PivotLast || options.Partition != PartitionLomuto:
+		return nil, fmt.Errorf("algorithm %s does not support pivot or partition options", name)
+	case algorithm.SortCompare != nil:
+		return algorithm.SortCompare, nil
+	default:
+		return nil, fmt.Errorf("algorithm %s is not a comparison sort", name)
+	}
+}
+
+func All() []Algorithm {
+	all := make([]Algorithm, 0, len(registryOrder))
+	for _, kind := range GetAllKinds() {
+		all = append(all, ByKind(kind)...)
+	}
+	return all
+}
+
+func ByKind(kind Kind) []Algorithm {
+	var matching []Algorithm
+	for _, name := range registryOrder {
+		if registry[name].Kind == kind {
+			matching = append(matching, registry[name])
+		}
+	}
+	return matching
+}
+
+func Names(kind Kind) []string {
+	var names []string
+	for _, algorithm := range ByKind(kind) {
+		names = append(names, algorithm.Name)
+	}
+	return names
+}
+
+func AllNames() []string {
+	var names []string
+	for _, algorithm := range All() {
+		names = append(names, algorithm.Name)
+	}
+	return names
+}
+
+func GetAllKinds() []Kind {
+	return []Kind{Search, Sort, Select}
+}
+
+func GetKindName(kind Kind) string {
+	switch kind {
+	case Search:
+		return "Search"
+	case Sort:
+		return "Sort"
+	case Select:
+		return "Select"
+	default:
+		return "Unknown"
+	}
+}
+
+func interruptibleElementSorts(
+	int32Sort func(arr []int32, o ops[int32]) []int32,
+	int64Sort func(arr []int64, o ops[int64]) []int64,
+	float64Sort func(arr []float64, o ops[float64]) []float64,
+	stringSort func(arr []string, o ops[string]) []string,
+	recordSort func(arr []Record, o ops[Record]) []Record,
+) ElementSorts {
+	return ElementSorts{
+		Int32: func(arr []int32, options SortOptions) []int32 {
+			return int32Sort(arr, sortOps(cmp.Compare[int32], options))
+		},
+		Int64: func(arr []int64, options SortOptions) []int64 {
+			return int64Sort(arr, sortOps(cmp.Compare[int64], options))
+		},
+		Float64: func(arr []float64, options SortOptions) []float64 {
+			return float64Sort(arr, sortOps(cmp.Compare[float64], options))
+		},
+		String: func(arr []string, options SortOptions) []string {
+			return stringSort(arr, sortOps(cmp.Compare[string], options))
+		},
+		Record: func(arr []Record, options SortOptions) []Record {
+			return recordSort(arr, sortOps(CompareRecords, options))
+		},
+	}
+}
+
+func ElementSortsWithOptions(
+	int32Sort func(arr []int32, options SortOptions) []int32,
+	int64Sort func(arr []int64, options SortOptions) []int64,
+	float64Sort func(arr []float64, options SortOptions) []float64,
+	stringSort func(arr []string, options SortOptions) []string,
+	recordSort func(arr []Record, compare func(a, b Record) int, options SortOptions) []Record,
+) ElementSorts {
+	return ElementSorts{
+		Int32:   int32Sort,
+		Int64:   int64Sort,
+		Float64: float64Sort,
+		String:  stringSort,
+		Record: func(arr []Record, options SortOptions) []Record {
+			return recordSort(arr, CompareRecords, options)
+		},
+	}
+}
+
+func OrderedElementSorts(
+	int32Sort func(arr []int32) []int32,
+	int64Sort func(arr []int64) []int64,
+	float64Sort func(arr []float64) []float64,
+	stringSort func(arr []string) []string,
+	recordSort func(arr []Record, compare func(a, b Record) int) []Record,
+) ElementSorts {
+	return ElementSorts{
+		Int32: func(arr []int32, options SortOptions) []int32 {
+			return int32Sort(arr)
+		},
+		Int64: func(arr []int64, options SortOptions) []int64 {
+			return int64Sort(arr)
+		},
+		Float64: func(arr []float64, options SortOptions) []float64 {
+			return float64Sort(arr)
+		},
+		String: func(arr []string, options SortOptions) []string {
+			return stringSort(arr)
+		},
+		Record: func(arr []Record, options SortOptions) []Record {
+			return recordSort(arr, CompareRecords)
+		},
+	}
+}
+
+func IntegerElementSorts(int32Sort func(arr []int32) []int32, int64Sort func(arr []int64) []int64) ElementSorts {
+	return ElementSorts{
+		Int32: func(arr []int32, options SortOptions) []int32 {
+			return int32Sort(arr)
+		},
+		Int64: func(arr []int64, options SortOptions) []int64 {
+			return int64Sort(arr)
+		},
+	}
+}
+
+func SearchUnsorted(search SearchFunc) SearchFunc {
+	return func(arr []int, target int) int {
+		sorted, order := SortedWithIndex(arr)
+		
+		index := search(sorted, target)
+		if index == -1 {
+			return -1
+		}
+		return order[index]
+	}
+}
+
+func SortedWithIndex(arr []int) ([]int, []int) {
+	order := make([]int, len(arr))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(arr[a], arr[b])
+	})
+	
+	sorted := make([]int, len(arr))
+	for i, j := range order {
+		sorted[i] = arr[j]
+	}
+	return sorted, order
+}
@@ -1,50 +1,107 @@
 package algorithms
 
-import "sort"
+import "cmp"
+
+func init() {
+	Register(Algorithm{
+		Name:        "linear_search",
+		DisplayName: "Linear Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			BestCase:    "O(1)",
+			AverageCase: "O(n)",
+			WorstCase:   "O(n)",
+			Space:       "O(1)",
+		},
+		Search:        LinearSearch,
+		CountedSearch: LinearSearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "binary_search",
+		DisplayName: "Binary Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(1)",
+		},
+		Search:        BinarySearch,
+		CountedSearch: BinarySearchCounted,
+	})
+}
 
 func LinearSearch(arr []int, target int) int {
-	for i, v := range arr {
-		if v == target {
+	return LinearSearchOrdered(arr, target)
+}
+
+func LinearSearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return linearSearch(arr, target)
+}
+
+func LinearSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return linearSearchFunc(arr, target, compare)
+}
+
+func LinearSearchCounted(arr []int, target int, counter *Counter) int {
+	return linearSearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func linearSearch[T cmp.Ordered](arr []T, target T) int {
+	for i := range arr {
+		if arr[i] == target {
 			return i
 		}
 	}
 	return -1
 }
 
-func BinarySearch(arr []int, target int) int {
-	left, right := 0, len(arr)-1
-	
-	for left <= right {
-		mid := left + (right-left)/2
-		if arr[mid] == target {
-			return mid
-		} else if arr[mid] < target {
-			left = mid + 1
-		} else {
-			right = mid - 1
+func linearSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	for i := range arr {
+		if compare(arr[i], target) == 0 {
+			return i
 		}
 	}
 	return -1
 }
 
-func BinarySearchSorted(arr []int, target int) int {
-	return BinarySearch(arr, target)
+func BinarySearch(arr []int, target int) int {
+	return BinarySearchOrdered(arr, target)
 }
 
-func BinarySearchUnsorted(arr []int, target int) int {
-	sorted := make([]int, len(arr))
-	copy(sorted, arr)
-	sort.Ints(sorted)
-	
-	index := BinarySearch(sorted, target)
-	if index == -1 {
-		return -1
+func BinarySearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return binarySearch(arr, target)
+}
+
+func BinarySearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return binarySearchFunc(arr, target, compare)
+}
+
+func BinarySearchCounted(arr []int, target int, counter *Counter) int {
+	return binarySearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func binarySearch[T cmp.Ordered](arr []T, target T) int {
+	index := lowerBound(arr, target)
+	if index < len(arr) && arr[index] == target {
+		return index
 	}
-	
-	for i, v := range arr {
-		if v == sorted[index] {
-			return i
-		}
+	return -1
+}
+
+func binarySearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	index := lowerBoundFunc(arr, target, compare)
+	if index < len(arr) && compare(arr[index], target) == 0 {
+		return index
 	}
 	return -1
 }
+
+func BinarySearchSorted(arr []int, target int) int {
+	return BinarySearch(arr, target)
+}
+
+func BinarySearchUnsorted(arr []int, target int) int {
+	return SearchUnsorted(BinarySearch)(arr, target)
+}
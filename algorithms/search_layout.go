@@ -0,0 +1,361 @@
+package algorithms
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+const BTreeBlockSize = 16
+
+func init() {
+	Register(Algorithm{
+		Name:        "branchless_search",
+		DisplayName: "Branchless Binary Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(log n)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(1)",
+		},
+		Search:        BranchlessSearch,
+		CountedSearch: BranchlessSearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "eytzinger_search",
+		DisplayName: "Eytzinger Layout Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(log n)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(n)",
+		},
+		Layout: eytzingerLayout,
+	})
+	Register(Algorithm{
+		Name:        "btree_search",
+		DisplayName: "B-Tree Layout Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(log n)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(n)",
+		},
+		Layout: bTreeLayout,
+	})
+}
+
+func BranchlessLowerBound(arr []int, target int) int {
+	return BranchlessLowerBoundOrdered(arr, target)
+}
+
+func BranchlessLowerBoundOrdered[T cmp.Ordered](arr []T, target T) int {
+	return branchlessLowerBound(arr, target)
+}
+
+func branchlessLowerBound[T cmp.Ordered](arr []T, target T) int {
+	n := len(arr)
+	if n == 0 {
+		return 0
+	}
+	
+	base := 0
+	for n > 1 {
+		half := n / 2
+		if arr[base+half-1] < target {
+			base += half
+		}
+		n -= half
+	}
+	
+	if arr[base] < target {
+		base++
+	}
+	return base
+}
+
+func branchlessLowerBoundCounted[T cmp.Ordered](arr []T, target T, counter *Counter) int {
+	n := len(arr)
+	if n == 0 {
+		return 0
+	}
+	
+	base := 0
+	for n > 1 {
+		half := n / 2
+		counter.read(1)
+		counter.compared(1)
+		if arr[base+half-1] < target {
+			base += half
+		}
+		n -= half
+	}
+	
+	counter.read(1)
+	counter.compared(1)
+	if arr[base] < target {
+		base++
+	}
+	return base
+}
+
+func BranchlessSearch(arr []int, target int) int {
+	return BranchlessSearchOrdered(arr, target)
+}
+
+func BranchlessSearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return branchlessSearch(arr, target)
+}
+
+func BranchlessSearchCounted(arr []int, target int, counter *Counter) int {
+	return branchlessSearchCounted(arr, target, counter)
+}
+
+func branchlessSearch[T cmp.Ordered](arr []T, target T) int {
+	i := branchlessLowerBound(arr, target)
+	if i < len(arr) && arr[i] == target {
+		return i
+	}
+	return -1
+}
+
+func branchlessSearchCounted[T cmp.Ordered](arr []T, target T, counter *Counter) int {
+	i := branchlessLowerBoundCounted(arr, target, counter)
+	if i < len(arr) {
+		counter.read(1)
+		counter.compared(1)
+		if arr[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+type eytzingerNode[T cmp.Ordered] struct {
+	value T
+	index int
+}
+
+type EytzingerLayout[T cmp.Ordered] struct {
+	nodes []eytzingerNode[T]
+}
+
+func NewEytzingerLayout[T cmp.Ordered](sorted []T) *EytzingerLayout[T] {
+	layout := &EytzingerLayout[T]{
+		nodes: make([]eytzingerNode[T], len(sorted)+1),
+	}
+	
+	next := 0
+	layout.build(sorted, 1, &next)
+	return layout
+}
+
+func (l *EytzingerLayout[T]) build(sorted []T, k int, next *int) {
+	if k >= len(l.nodes) {
+		return
+	}
+	
+	l.build(sorted, 2*k, next)
+	l.nodes[k] = eytzingerNode[T]{value: sorted[*next], index: *next}
+	*next++
+	l.build(sorted, 2*k+1, next)
+}
+
+func (l *EytzingerLayout[T]) lowerBoundSlot(target T) int {
+	n := len(l.nodes) - 1
+	
+	k := 1
+	for k <= n {
+		if l.nodes[k].value < target {
+			k = 2*k + 1
+		} else {
+			k = 2 * k
+		}
+	}
+	return k >> (uint(bits.TrailingZeros(^uint(k))) + 1)
+}
+
+func (l *EytzingerLayout[T]) lowerBoundSlotCounted(target T, counter *Counter) int {
+	n := len(l.nodes) - 1
+	
+	k := 1
+	for k <= n {
+		counter.read(1)
+		counter.compared(1)
+		if l.nodes[k].value < target {
+			k = 2*k + 1
+		} else {
+			k = 2 * k
+		}
+	}
+	return k >> (uint(bits.TrailingZeros(^uint(k))) + 1)
+}
+
+func (l *EytzingerLayout[T]) LowerBound(target T) int {
+	k := l.lowerBoundSlot(target)
+	if k == 0 {
+		return len(l.nodes) - 1
+	}
+	return l.nodes[k].index
+}
+
+func (l *EytzingerLayout[T]) Search(target T) int {
+	k := l.lowerBoundSlot(target)
+	if k != 0 && l.nodes[k].value == target {
+		return l.nodes[k].index
+	}
+	return -1
+}
+
+func (l *EytzingerLayout[T]) searchCounted(target T, counter *Counter) int {
+	k := l.lowerBoundSlotCounted(target, counter)
+	if k != 0 {
+		counter.read(1)
+		counter.compared(1)
+		if l.nodes[k].value == target {
+			return l.nodes[k].index
+		}
+	}
+	return -1
+}
+
+type BTreeLayout[T cmp.Ordered] struct {
+	values []T
+	index  []int
+	blocks int
+	size   int
+}
+
+func NewBTreeLayout[T cmp.Ordered](sorted []T) *BTreeLayout[T] {
+	blocks := (len(sorted) + BTreeBlockSize - 1) / BTreeBlockSize
+	layout := &BTreeLayout[T]{
+		values: make([]T, blocks*BTreeBlockSize),
+		index:  make([]int, blocks*BTreeBlockSize),
+		blocks: blocks,
+		size:   len(sorted),
+	}
+	
+	for i := range layout.index {
+		layout.index[i] = -1
+	}
+	
+	next := 0
+	layout.build(sorted, 0, &next)
+	return layout
+}
+
+func bTreeChild(k, i int) int {
+	return k*(BTreeBlockSize+1) + i + 1
+}
+
+func (l *BTreeLayout[T]) build(sorted []T, k int, next *int) {
+	if k >= l.blocks {
+		return
+	}
+	
+	for i := 0; i < BTreeBlockSize; i++ {
+		l.build(sorted, bTreeChild(k, i), next)
+		if *next < len(sorted) {
+			l.values[k*BTreeBlockSize+i] = sorted[*next]
+			l.index[k*BTreeBlockSize+i] = *next
+			*next++
+		}
+	}
+	l.build(sorted, bTreeChild(k, BTreeBlockSize), next)
+}
+
+func (l *BTreeLayout[T]) lowerBoundSlot(target T) int {
+	slot := -1
+	
+	k := 0
+	for k < l.blocks {
+		base := k * BTreeBlockSize
+		i := 0
+		for i < BTreeBlockSize && l.index[base+i] >= 0 && l.values[base+i] < target {
+			i++
+		}
+		if i < BTreeBlockSize && l.index[base+i] >= 0 {
+			slot = base + i
+		}
+		k = bTreeChild(k, i)
+	}
+	
+	return slot
+}
+
+func (l *BTreeLayout[T]) lowerBoundSlotCounted(target T, counter *Counter) int {
+	slot := -1
+	
+	k := 0
+	for k < l.blocks {
+		base := k * BTreeBlockSize
+		i := 0
+		for i < BTreeBlockSize && l.index[base+i] >= 0 {
+			counter.read(1)
+			counter.compared(1)
+			if !(l.values[base+i] < target) {
+				break
+			}
+			i++
+		}
+		if i < BTreeBlockSize && l.index[base+i] >= 0 {
+			slot = base + i
+		}
+		k = bTreeChild(k, i)
+	}
+	
+	return slot
+}
+
+func (l *BTreeLayout[T]) LowerBound(target T) int {
+	slot := l.lowerBoundSlot(target)
+	if slot == -1 {
+		return l.size
+	}
+	return l.index[slot]
+}
+
+func (l *BTreeLayout[T]) Search(target T) int {
+	slot := l.lowerBoundSlot(target)
+	if slot != -1 && l.values[slot] == target {
+		return l.index[slot]
+	}
+	return -1
+}
+
+func (l *BTreeLayout[T]) searchCounted(target T, counter *Counter) int {
+	slot := l.lowerBoundSlotCounted(target, counter)
+	if slot != -1 {
+		counter.read(1)
+		counter.compared(1)
+		if l.values[slot] == target {
+			return l.index[slot]
+		}
+	}
+	return -1
+}
+
+func eytzingerLayout(sorted []int, counter *Counter) func(target int) int {
+	layout := NewEytzingerLayout(sorted)
+	if counter == nil {
+		return layout.Search
+	}
+	return func(target int) int {
+		return layout.searchCounted(target, counter)
+	}
+}
+
+func bTreeLayout(sorted []int, counter *Counter) func(target int) int {
+	layout := NewBTreeLayout(sorted)
+	if counter == nil {
+		return layout.Search
+	}
+	return func(target int) int {
+		return layout.searchCounted(target, counter)
+	}
+}
@@ -0,0 +1,64 @@
+package algorithms
+
+import "cmp"
+
+func init() {
+	Register(Algorithm{
+		Name:        "quick_select",
+		DisplayName: "Quick Select",
+		Kind:        Select,
+		Metadata: Metadata{
+			InPlace:     true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n)",
+			WorstCase:   "O(n²)",
+			Space:       "O(1)",
+		},
+		Select:        QuickSelect,
+		CountedSelect: QuickSelectCounted,
+	})
+}
+
+func QuickSelect(arr []int, k int) (int, bool) {
+	return QuickSelectOrdered(arr, k)
+}
+
+func QuickSelectOrdered[T cmp.Ordered](arr []T, k int) (T, bool) {
+	return quickSelect(arr, k, newOps(cmp.Compare[T], nil))
+}
+
+func QuickSelectFunc[T any](arr []T, k int, compare func(a, b T) int) (T, bool) {
+	return quickSelect(arr, k, newOps(compare, nil))
+}
+
+func QuickSelectCounted(arr []int, k int, counter *Counter) (int, bool) {
+	return quickSelect(arr, k, newOps(cmp.Compare[int], counter))
+}
+
+func quickSelect[T any](arr []T, k int, o ops[T]) (T, bool) {
+	if k < 0 || k >= len(arr) {
+		var zero T
+		return zero, false
+	}
+	result := o.input(arr)
+	
+	low, high := 0, result.len()-1
+	for low < high {
+		pi := selectPartition(result, low, high, o)
+		if pi == k {
+			return o.at(result, pi), true
+		} else if pi < k {
+			low = pi + 1
+		} else {
+			high = pi - 1
+		}
+	}
+	return o.at(result, k), true
+}
+
+func selectPartition[T any](arr array[T], low, high int, o ops[T]) int {
+	if high-low < 2 {
+		return partition(arr, low, high, o)
+	}
+	return medianOfThreePartition(arr, low, high+1, o)
+}
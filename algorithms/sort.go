@@ -1,21 +1,153 @@
 package algorithms
 
 import (
-	"math/rand"
+	"cmp"
+	"slices"
 	"sort"
-	"time"
 )
 
+func init() {
+	Register(Algorithm{
+		Name:        "bubble_sort",
+		DisplayName: "Bubble Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			InPlace:     true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n²)",
+			WorstCase:   "O(n²)",
+			Space:       "O(1)",
+		},
+		Sort:        BubbleSort,
+		CountedSort: BubbleSortCounted,
+		SortCompare: BubbleSortFunc[int],
+		Elements:    interruptibleElementSorts(bubbleSort[int32], bubbleSort[int64], bubbleSort[float64], bubbleSort[string], bubbleSort[Record]),
+		
+		Interruptible: interruptibleSort(bubbleSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "insertion_sort",
+		DisplayName: "Insertion Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			InPlace:     true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n²)",
+			WorstCase:   "O(n²)",
+			Space:       "O(1)",
+		},
+		Sort:        InsertionSort,
+		CountedSort: InsertionSortCounted,
+		SortCompare: InsertionSortFunc[int],
+		Elements:    interruptibleElementSorts(insertionSort[int32], insertionSort[int64], insertionSort[float64], insertionSort[string], insertionSort[Record]),
+		
+		Interruptible: interruptibleSort(insertionSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "merge_sort",
+		DisplayName: "Merge Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      true,
+			InPlace:     false,
+			BestCase:    "O(n log n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(n)",
+		},
+		Sort:        MergeSort,
+		CountedSort: MergeSortCounted,
+		SortCompare: MergeSortFunc[int],
+		Elements:    interruptibleElementSorts(mergeSort[int32], mergeSort[int64], mergeSort[float64], mergeSort[string], mergeSort[Record]),
+		
+		Interruptible: interruptibleSort(mergeSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "quick_sort",
+		DisplayName: "Quick Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      false,
+			InPlace:     true,
+			BestCase:    "O(n log n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n²)",
+			Space:       "O(log n)",
+		},
+		Sort:            QuickSort,
+		CountedSort:     QuickSortCounted,
+		SortWithOptions: QuickSortWithOptions,
+		SortCompare:     QuickSortFunc[int],
+		
+		SortCompareWithOptions: QuickSortFuncWithOptions[int],
+		Elements:               ElementSortsWithOptions(QuickSortOrderedWithOptions[int32], QuickSortOrderedWithOptions[int64], QuickSortOrderedWithOptions[float64], QuickSortOrderedWithOptions[string], QuickSortFuncWithOptions[Record]),
+	})
+	Register(Algorithm{
+		Name:        "heap_sort",
+		DisplayName: "Heap Sort",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      false,
+			InPlace:     true,
+			BestCase:    "O(n log n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(1)",
+		},
+		Sort:        HeapSort,
+		CountedSort: HeapSortCounted,
+		SortCompare: HeapSortFunc[int],
+		Elements:    interruptibleElementSorts(heapSort[int32], heapSort[int64], heapSort[float64], heapSort[string], heapSort[Record]),
+		
+		Interruptible: interruptibleSort(heapSort[int]),
+	})
+	Register(Algorithm{
+		Name:        "native_sort",
+		DisplayName: "Native Sort (Go)",
+		Kind:        Sort,
+		Metadata: Metadata{
+			Stable:      false,
+			InPlace:     true,
+			BestCase:    "O(n)",
+			AverageCase: "O(n log n)",
+			WorstCase:   "O(n log n)",
+			Space:       "O(log n)",
+		},
+		Sort:        NativeSort,
+		CountedSort: NativeSortCounted,
+		SortCompare: NativeSortFunc[int],
+		Elements:    OrderedElementSorts(NativeSortOrdered[int32], NativeSortOrdered[int64], NativeSortOrdered[float64], NativeSortOrdered[string], NativeSortFunc[Record]),
+	})
+}
+
 func BubbleSort(arr []int) []int {
-	result := make([]int, len(arr))
-	copy(result, arr)
+	return BubbleSortOrdered(arr)
+}
+
+func BubbleSortOrdered[T cmp.Ordered](arr []T) []T {
+	return bubbleSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func BubbleSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return bubbleSort(arr, newOps(compare, nil))
+}
+
+func BubbleSortCounted(arr []int, counter *Counter) []int {
+	return bubbleSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func bubbleSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
 	
-	n := len(result)
+	n := result.len()
 	for i := 0; i < n-1; i++ {
+		o.checkpoint()
 		swapped := false
 		for j := 0; j < n-i-1; j++ {
-			if result[j] > result[j+1] {
-				result[j], result[j+1] = result[j+1], result[j]
+			if o.compareAt(result, j, j+1) > 0 {
+				o.swap(result, j, j+1)
 				swapped = true
 			}
 		}
@@ -23,134 +155,211 @@ func BubbleSort(arr []int) []int {
 			break
 		}
 	}
-	return result
+	return result.values
 }
 
 func InsertionSort(arr []int) []int {
-	result := make([]int, len(arr))
-	copy(result, arr)
+	return InsertionSortOrdered(arr)
+}
+
+func InsertionSortOrdered[T cmp.Ordered](arr []T) []T {
+	return insertionSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func InsertionSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return insertionSort(arr, newOps(compare, nil))
+}
+
+func InsertionSortCounted(arr []int, counter *Counter) []int {
+	return insertionSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func insertionSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
 	
-	for i := 1; i < len(result); i++ {
-		key := result[i]
-		j := i - 1
-		for j >= 0 && result[j] > key {
-			result[j+1] = result[j]
-			j--
-		}
-		result[j+1] = key
-	}
-	return result
+	insertionSortInPlace(result, o)
+	return result.values
 }
 
 func MergeSort(arr []int) []int {
-	if len(arr) <= 1 {
+	return MergeSortOrdered(arr)
+}
+
+func MergeSortOrdered[T cmp.Ordered](arr []T) []T {
+	return mergeSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func MergeSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return mergeSort(arr, newOps(compare, nil))
+}
+
+func MergeSortCounted(arr []int, counter *Counter) []int {
+	return mergeSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func mergeSort[T any](arr []T, o ops[T]) []T {
+	return mergeSortRange(o.input(arr), o).values
+}
+
+func mergeSortRange[T any](arr array[T], o ops[T]) array[T] {
+	if arr.len() <= 1 {
 		return arr
 	}
+	o.checkpoint()
+	o.enter(arr, 0, arr.len())
+	defer o.exit(arr, 0, arr.len())
 	
-	mid := len(arr) / 2
-	left := MergeSort(arr[:mid])
-	right := MergeSort(arr[mid:])
+	mid := arr.len() / 2
+	left := mergeSortRange(arr.slice(0, mid), o)
+	right := mergeSortRange(arr.slice(mid, arr.len()), o)
 	
-	return merge(left, right)
+	return merge(left, right, o)
 }
 
-func merge(left, right []int) []int {
-	result := make([]int, 0, len(left)+len(right))
-	i, j := 0, 0
+func merge[T any](left, right array[T], o ops[T]) array[T] {
+	result := left.resized(make([]T, left.len()+right.len()))
+	i, j, k := 0, 0, 0
 	
-	for i < len(left) && j < len(right) {
-		if left[i] <= right[j] {
-			result = append(result, left[i])
+	for i < left.len() && j < right.len() {
+		l := o.at(left, i)
+		if o.compareValue(l, right, j) <= 0 {
+			o.set(result, k, l)
 			i++
 		} else {
-			result = append(result, right[j])
+			o.move(result, k, right, j)
 			j++
 		}
+		k++
 	}
 	
-	result = append(result, left[i:]...)
-	result = append(result, right[j:]...)
+	k += o.copy(result.slice(k, result.len()), left.slice(i, left.len()))
+	o.copy(result.slice(k, result.len()), right.slice(j, right.len()))
 	
 	return result
 }
 
 func QuickSort(arr []int) []int {
-	if len(arr) <= 1 {
-		return arr
-	}
-	
-	result := make([]int, len(arr))
-	copy(result, arr)
-	
-	rand.Seed(time.Now().UnixNano())
-	quickSortHelper(result, 0, len(result)-1)
-	
-	return result
+	return QuickSortOrdered(arr)
 }
 
-func quickSortHelper(arr []int, low, high int) {
-	if low < high {
-		pi := partition(arr, low, high)
-		quickSortHelper(arr, low, pi-1)
-		quickSortHelper(arr, pi+1, high)
-	}
+func QuickSortOrdered[T cmp.Ordered](arr []T) []T {
+	return QuickSortOrderedWithOptions(arr, SortOptions{})
+}
+
+func QuickSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return QuickSortFuncWithOptions(arr, compare, SortOptions{})
+}
+
+func QuickSortCounted(arr []int, counter *Counter) []int {
+	return QuickSortWithOptions(arr, SortOptions{Counter: counter})
 }
 
-func partition(arr []int, low, high int) int {
-	pivot := arr[high]
+func partition[T any](arr array[T], low, high int, o ops[T]) int {
+	pivot := o.at(arr, high)
 	i := low - 1
 	
 	for j := low; j < high; j++ {
-		if arr[j] <= pivot {
+		if o.compareTo(arr, j, pivot) <= 0 {
 			i++
-			arr[i], arr[j] = arr[j], arr[i]
+			o.swap(arr, i, j)
 		}
 	}
 	
-	arr[i+1], arr[high] = arr[high], arr[i+1]
+	o.swap(arr, i+1, high)
 	return i + 1
 }
 
 func HeapSort(arr []int) []int {
-	result := make([]int, len(arr))
-	copy(result, arr)
+	return HeapSortOrdered(arr)
+}
+
+func HeapSortOrdered[T cmp.Ordered](arr []T) []T {
+	return heapSort(arr, newOps(cmp.Compare[T], nil))
+}
+
+func HeapSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	return heapSort(arr, newOps(compare, nil))
+}
+
+func HeapSortCounted(arr []int, counter *Counter) []int {
+	return heapSort(arr, newOps(cmp.Compare[int], counter))
+}
+
+func heapSort[T any](arr []T, o ops[T]) []T {
+	result := o.input(arr)
 	
-	n := len(result)
+	n := result.len()
 	
 	for i := n/2 - 1; i >= 0; i-- {
-		heapify(result, n, i)
+		heapify(result, n, i, o)
 	}
 	
 	for i := n - 1; i > 0; i-- {
-		result[0], result[i] = result[i], result[0]
-		heapify(result, i, 0)
+		o.checkpoint()
+		o.swap(result, 0, i)
+		heapify(result, i, 0, o)
 	}
 	
-	return result
+	return result.values
 }
 
-func heapify(arr []int, n, i int) {
+func heapify[T any](arr array[T], n, i int, o ops[T]) {
 	largest := i
 	left := 2*i + 1
 	right := 2*i + 2
 	
-	if left < n && arr[left] > arr[largest] {
+	if left < n && o.compareAt(arr, left, largest) > 0 {
 		largest = left
 	}
 	
-	if right < n && arr[right] > arr[largest] {
+	if right < n && o.compareAt(arr, right, largest) > 0 {
 		largest = right
 	}
 	
 	if largest != i {
-		arr[i], arr[largest] = arr[largest], arr[i]
-		heapify(arr, n, largest)
+		o.swap(arr, i, largest)
+		heapify(arr, n, largest, o)
 	}
 }
 
 func NativeSort(arr []int) []int {
-	result := make([]int, len(arr))
+	return NativeSortOrdered(arr)
+}
+
+func NativeSortOrdered[T cmp.Ordered](arr []T) []T {
+	result := make([]T, len(arr))
 	copy(result, arr)
-	sort.Ints(result)
+	slices.Sort(result)
 	return result
 }
+
+func NativeSortFunc[T any](arr []T, compare func(a, b T) int) []T {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	slices.SortFunc(result, compare)
+	return result
+}
+
+func NativeSortCounted(arr []int, counter *Counter) []int {
+	o := newOps(cmp.Compare[int], counter)
+	result := o.input(arr)
+	sort.Sort(countedInts{result, o})
+	return result.values
+}
+
+type countedInts struct {
+	arr array[int]
+	o   ops[int]
+}
+
+func (c countedInts) Len() int {
+	return c.arr.len()
+}
+
+func (c countedInts) Less(i, j int) bool {
+	return c.o.compareAt(c.arr, i, j) < 0
+}
+
+func (c countedInts) Swap(i, j int) {
+	c.o.swap(c.arr, i, j)
+}
@@ -0,0 +1,490 @@
+package algorithms
+
+import (
+	"cmp"
+	"math"
+)
+
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+func init() {
+	Register(Algorithm{
+		Name:        "interpolation_search",
+		DisplayName: "Interpolation Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(log log n)",
+			WorstCase:      "O(n)",
+			Space:          "O(1)",
+		},
+		Search:        InterpolationSearch,
+		CountedSearch: InterpolationSearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "exponential_search",
+		DisplayName: "Exponential Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(log i)",
+			WorstCase:      "O(log n)",
+			Space:          "O(1)",
+		},
+		Search:        ExponentialSearch,
+		CountedSearch: ExponentialSearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "jump_search",
+		DisplayName: "Jump Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(√n)",
+			WorstCase:      "O(√n)",
+			Space:          "O(1)",
+		},
+		Search:        JumpSearch,
+		CountedSearch: JumpSearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "ternary_search",
+		DisplayName: "Ternary Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(1)",
+		},
+		Search:        TernarySearch,
+		CountedSearch: TernarySearchCounted,
+	})
+	Register(Algorithm{
+		Name:        "fibonacci_search",
+		DisplayName: "Fibonacci Search",
+		Kind:        Search,
+		Metadata: Metadata{
+			RequiresSorted: true,
+			BestCase:       "O(1)",
+			AverageCase:    "O(log n)",
+			WorstCase:      "O(log n)",
+			Space:          "O(1)",
+		},
+		Search:        FibonacciSearch,
+		CountedSearch: FibonacciSearchCounted,
+	})
+}
+
+func InterpolationSearch(arr []int, target int) int {
+	return InterpolationSearchNumber(arr, target)
+}
+
+func InterpolationSearchNumber[T Number](arr []T, target T) int {
+	return interpolationSearch(arr, target)
+}
+
+func InterpolationSearchCounted(arr []int, target int, counter *Counter) int {
+	return interpolationSearchCounted(arr, target, counter)
+}
+
+func interpolationSearch[T Number](arr []T, target T) int {
+	low, high := 0, len(arr)-1
+	
+	for low <= high {
+		if !(target >= arr[low] && target <= arr[high]) {
+			break
+		}
+		
+		if arr[high] == arr[low] {
+			if arr[low] == target {
+				return low
+			}
+			return -1
+		}
+		
+		fraction := (float64(target) - float64(arr[low])) / (float64(arr[high]) - float64(arr[low]))
+		pos := low + int(fraction*float64(high-low))
+		if pos < low || pos > high {
+			pos = low + (high-low)/2
+		}
+		
+		if arr[pos] == target {
+			return pos
+		}
+		if arr[pos] < target {
+			low = pos + 1
+		} else {
+			high = pos - 1
+		}
+	}
+	return -1
+}
+
+func interpolationSearchCounted[T Number](arr []T, target T, counter *Counter) int {
+	low, high := 0, len(arr)-1
+	
+	for low <= high {
+		counter.read(2)
+		counter.compared(2)
+		if !(target >= arr[low] && target <= arr[high]) {
+			break
+		}
+		
+		counter.compared(1)
+		if arr[high] == arr[low] {
+			counter.compared(1)
+			if arr[low] == target {
+				return low
+			}
+			return -1
+		}
+		
+		fraction := (float64(target) - float64(arr[low])) / (float64(arr[high]) - float64(arr[low]))
+		pos := low + int(fraction*float64(high-low))
+		if pos < low || pos > high {
+			pos = low + (high-low)/2
+		}
+		
+		counter.read(1)
+		counter.compared(1)
+		if arr[pos] == target {
+			return pos
+		}
+		counter.compared(1)
+		if arr[pos] < target {
+			low = pos + 1
+		} else {
+			high = pos - 1
+		}
+	}
+	return -1
+}
+
+func ExponentialSearch(arr []int, target int) int {
+	return ExponentialSearchOrdered(arr, target)
+}
+
+func ExponentialSearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return exponentialSearch(arr, target)
+}
+
+func ExponentialSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return exponentialSearchFunc(arr, target, compare)
+}
+
+func ExponentialSearchCounted(arr []int, target int, counter *Counter) int {
+	return exponentialSearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func exponentialSearch[T cmp.Ordered](arr []T, target T) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	if arr[0] == target {
+		return 0
+	}
+	
+	bound := 1
+	for bound < n && cmp.Less(arr[bound], target) {
+		bound *= 2
+	}
+	
+	low := bound / 2
+	high := bound
+	if high >= n {
+		high = n - 1
+	}
+	
+	index := binarySearch(arr[low:high+1], target)
+	if index == -1 {
+		return -1
+	}
+	return low + index
+}
+
+func exponentialSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	if compare(arr[0], target) == 0 {
+		return 0
+	}
+	
+	bound := 1
+	for bound < n && compare(arr[bound], target) < 0 {
+		bound *= 2
+	}
+	
+	low := bound / 2
+	high := bound
+	if high >= n {
+		high = n - 1
+	}
+	
+	index := binarySearchFunc(arr[low:high+1], target, compare)
+	if index == -1 {
+		return -1
+	}
+	return low + index
+}
+
+func JumpSearch(arr []int, target int) int {
+	return JumpSearchOrdered(arr, target)
+}
+
+func JumpSearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return jumpSearch(arr, target)
+}
+
+func JumpSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return jumpSearchFunc(arr, target, compare)
+}
+
+func JumpSearchCounted(arr []int, target int, counter *Counter) int {
+	return jumpSearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func jumpSearch[T cmp.Ordered](arr []T, target T) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	
+	step := int(math.Sqrt(float64(n)))
+	if step < 1 {
+		step = 1
+	}
+	
+	prev, next := 0, step
+	for next < n && cmp.Less(arr[next-1], target) {
+		prev = next
+		next += step
+	}
+	if next > n {
+		next = n
+	}
+	
+	for i := prev; i < next; i++ {
+		c := cmp.Compare(arr[i], target)
+		if c == 0 {
+			return i
+		} else if c > 0 {
+			break
+		}
+	}
+	return -1
+}
+
+func jumpSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	
+	step := int(math.Sqrt(float64(n)))
+	if step < 1 {
+		step = 1
+	}
+	
+	prev, next := 0, step
+	for next < n && compare(arr[next-1], target) < 0 {
+		prev = next
+		next += step
+	}
+	if next > n {
+		next = n
+	}
+	
+	for i := prev; i < next; i++ {
+		c := compare(arr[i], target)
+		if c == 0 {
+			return i
+		} else if c > 0 {
+			break
+		}
+	}
+	return -1
+}
+
+func TernarySearch(arr []int, target int) int {
+	return TernarySearchOrdered(arr, target)
+}
+
+func TernarySearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return ternarySearch(arr, target)
+}
+
+func TernarySearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return ternarySearchFunc(arr, target, compare)
+}
+
+func TernarySearchCounted(arr []int, target int, counter *Counter) int {
+	return ternarySearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func ternarySearch[T cmp.Ordered](arr []T, target T) int {
+	left, right := 0, len(arr)-1
+	
+	for left <= right {
+		third := (right - left) / 3
+		mid1 := left + third
+		mid2 := right - third
+		
+		c1 := cmp.Compare(arr[mid1], target)
+		if c1 == 0 {
+			return mid1
+		}
+		c2 := cmp.Compare(arr[mid2], target)
+		if c2 == 0 {
+			return mid2
+		}
+		
+		if c1 > 0 {
+			right = mid1 - 1
+		} else if c2 < 0 {
+			left = mid2 + 1
+		} else {
+			left = mid1 + 1
+			right = mid2 - 1
+		}
+	}
+	return -1
+}
+
+func ternarySearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	left, right := 0, len(arr)-1
+	
+	for left <= right {
+		third := (right - left) / 3
+		mid1 := left + third
+		mid2 := right - third
+		
+		c1 := compare(arr[mid1], target)
+		if c1 == 0 {
+			return mid1
+		}
+		c2 := compare(arr[mid2], target)
+		if c2 == 0 {
+			return mid2
+		}
+		
+		if c1 > 0 {
+			right = mid1 - 1
+		} else if c2 < 0 {
+			left = mid2 + 1
+		} else {
+			left = mid1 + 1
+			right = mid2 - 1
+		}
+	}
+	return -1
+}
+
+func FibonacciSearch(arr []int, target int) int {
+	return FibonacciSearchOrdered(arr, target)
+}
+
+func FibonacciSearchOrdered[T cmp.Ordered](arr []T, target T) int {
+	return fibonacciSearch(arr, target)
+}
+
+func FibonacciSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	return fibonacciSearchFunc(arr, target, compare)
+}
+
+func FibonacciSearchCounted(arr []int, target int, counter *Counter) int {
+	return fibonacciSearchFunc(arr, target, countedCompare(cmp.Compare[int], counter))
+}
+
+func fibonacciSearch[T cmp.Ordered](arr []T, target T) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	
+	fibM2, fibM1 := 0, 1
+	fibM := fibM2 + fibM1
+	for fibM < n {
+		fibM2 = fibM1
+		fibM1 = fibM
+		fibM = fibM2 + fibM1
+	}
+	
+	offset := -1
+	for fibM > 1 {
+		i := offset + fibM2
+		if i > n-1 {
+			i = n - 1
+		}
+		
+		c := cmp.Compare(arr[i], target)
+		if c < 0 {
+			fibM = fibM1
+			fibM1 = fibM2
+			fibM2 = fibM - fibM1
+			offset = i
+		} else if c > 0 {
+			fibM = fibM2
+			fibM1 = fibM1 - fibM2
+			fibM2 = fibM - fibM1
+		} else {
+			return i
+		}
+	}
+	
+	if fibM1 == 1 && offset+1 < n && arr[offset+1] == target {
+		return offset + 1
+	}
+	return -1
+}
+
+func fibonacciSearchFunc[T any](arr []T, target T, compare func(a, b T) int) int {
+	n := len(arr)
+	if n == 0 {
+		return -1
+	}
+	
+	fibM2, fibM1 := 0, 1
+	fibM := fibM2 + fibM1
+	for fibM < n {
+		fibM2 = fibM1
+		fibM1 = fibM
+		fibM = fibM2 + fibM1
+	}
+	
+	offset := -1
+	for fibM > 1 {
+		i := offset + fibM2
+		if i > n-1 {
+			i = n - 1
+		}
+		
+		c := compare(arr[i], target)
+		if c < 0 {
+			fibM = fibM1
+			fibM1 = fibM2
+			fibM2 = fibM - fibM1
+			offset = i
+		} else if c > 0 {
+			fibM = fibM2
+			fibM1 = fibM1 - fibM2
+			fibM2 = fibM - fibM1
+		} else {
+			return i
+		}
+	}
+	
+	if fibM1 == 1 && offset+1 < n && compare(arr[offset+1], target) == 0 {
+		return offset + 1
+	}
+	return -1
+}
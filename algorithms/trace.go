@@ -0,0 +1,173 @@
+package algorithms
+
+import "fmt"
+
+type TraceEventType string
+
+const (
+	TraceCompare TraceEventType = "compare"
+	TraceSwap    TraceEventType = "swap"
+	TraceWrite   TraceEventType = "write"
+	TraceEnter   TraceEventType = "enter"
+	TraceExit    TraceEventType = "exit"
+)
+
+const DefaultTraceLimit = 100000
+
+type TraceEvent struct {
+	Type  TraceEventType `json:"type"`
+	I     int            `json:"i"`
+	J     int            `json:"j"`
+	Value int            `json:"value"`
+}
+
+type Trace struct {
+	Algorithm string       `json:"algorithm"`
+	Input     []int        `json:"input"`
+	Output    []int        `json:"output"`
+	Events    []TraceEvent `json:"events"`
+	Truncated bool         `json:"truncated"`
+	
+	limit  int
+	arrays int
+}
+
+type array[T any] struct {
+	values []T
+	id     int
+	base   int
+}
+
+func TraceSort(name string, arr []int, options SortOptions, limit int) (*Trace, error) {
+	algorithm, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm: %s", name)
+	}
+	if algorithm.Kind != Sort {
+		return nil, fmt.Errorf("algorithm %s is not a sort", name)
+	}
+	if limit <= 0 {
+		limit = DefaultTraceLimit
+	}
+	
+	trace := &Trace{
+		Algorithm: name,
+		Input:     append([]int(nil), arr...),
+		Events:    make([]TraceEvent, 0),
+		limit:     limit,
+	}
+	options.Counter = &Counter{trace: trace}
+	
+	switch {
+	case algorithm.SortWithOptions != nil:
+		trace.Output = algorithm.SortWithOptions(arr, options)
+	case options.Pivot != PivotLast || options.Partition != PartitionLomuto:
+		return nil, fmt.Errorf("algorithm %s does not support pivot or partition options", name)
+	case algorithm.CountedSort != nil:
+		trace.Output = algorithm.CountedSort(arr, options.Counter)
+	default:
+		return nil, fmt.Errorf("algorithm %s does not support tracing", name)
+	}
+	
+	return trace, nil
+}
+
+func ReplayTrace(trace *Trace) []int {
+	arr := append([]int(nil), trace.Input...)
+	for _, event := range trace.Events {
+		switch event.Type {
+		case TraceSwap:
+			arr[event.I], arr[event.J] = arr[event.J], arr[event.I]
+		case TraceWrite:
+			arr[event.I] = event.Value
+		}
+	}
+	return arr
+}
+
+func (t *Trace) record(event TraceEvent) {
+	if len(t.Events) >= t.limit {
+		t.Truncated = true
+		return
+	}
+	t.Events = append(t.Events, event)
+}
+
+func scratch[T any](values []T) array[T] {
+	return array[T]{values: values}
+}
+
+func (a array[T]) len() int {
+	return len(a.values)
+}
+
+func (a array[T]) slice(low, high int) array[T] {
+	return array[T]{values: a.values[low:high], id: a.id, base: a.base + low}
+}
+
+func (a array[T]) resized(values []T) array[T] {
+	return array[T]{values: values, id: a.id, base: a.base}
+}
+
+func (o ops[T]) input(arr []T) array[T] {
+	result := make([]T, len(arr))
+	copy(result, arr)
+	return o.track(result)
+}
+
+func (o ops[T]) track(values []T) array[T] {
+	if o.counter == nil || o.counter.trace == nil {
+		return scratch(values)
+	}
+	o.counter.trace.arrays++
+	return array[T]{values: values, id: o.counter.trace.arrays}
+}
+
+func (o ops[T]) trace(arr array[T]) *Trace {
+	if arr.id == 0 {
+		return nil
+	}
+	return o.counter.trace
+}
+
+func (o ops[T]) traceCompare(arr array[T], i, j int) {
+	if t := o.trace(arr); t != nil {
+		if j >= 0 {
+			j += arr.base
+		}
+		t.record(TraceEvent{Type: TraceCompare, I: arr.base + i, J: j})
+	}
+}
+
+func (o ops[T]) traceSwap(arr array[T], i, j int) {
+	if t := o.trace(arr); t != nil {
+		t.record(TraceEvent{Type: TraceSwap, I: arr.base + i, J: arr.base + j})
+	}
+}
+
+func (o ops[T]) traceWrite(arr array[T], i int) {
+	if t := o.trace(arr); t != nil {
+		value, _ := any(arr.values[i]).(int)
+		t.record(TraceEvent{Type: TraceWrite, I: arr.base + i, Value: value})
+	}
+}
+
+func (o ops[T]) traceWrites(arr array[T]) {
+	for i := range arr.values {
+		o.traceWrite(arr, i)
+	}
+}
+
+func (o ops[T]) enter(arr array[T], low, high int) {
+	o.traceRange(TraceEnter, arr, low, high)
+}
+
+func (o ops[T]) exit(arr array[T], low, high int) {
+	o.traceRange(TraceExit, arr, low, high)
+}
+
+func (o ops[T]) traceRange(kind TraceEventType, arr array[T], low, high int) {
+	if t := o.trace(arr); t != nil && low < high {
+		t.record(TraceEvent{Type: kind, I: arr.base + low, J: arr.base + high})
+	}
+}
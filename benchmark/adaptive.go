@@ -0,0 +1,122 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	minSampleDuration = 200 * time.Microsecond
+	maxBatch          = 1 << 16
+)
+
+type AdaptiveRuns struct {
+	TargetPrecision float64
+	MinRuns         int
+	MaxRuns         int
+	TimeBudget      time.Duration
+}
+
+func DefaultAdaptiveRuns() AdaptiveRuns {
+	return AdaptiveRuns{
+		TargetPrecision: 0.02,
+		MinRuns:         5,
+		MaxRuns:         1000,
+		TimeBudget:      10 * time.Second,
+	}
+}
+
+func (a AdaptiveRuns) Enabled() bool {
+	return a.TargetPrecision > 0
+}
+
+func (a AdaptiveRuns) withDefaults() AdaptiveRuns {
+	defaults := DefaultAdaptiveRuns()
+	if a.MinRuns <= 0 {
+		a.MinRuns = defaults.MinRuns
+	}
+	if a.MaxRuns <= 0 {
+		a.MaxRuns = defaults.MaxRuns
+	}
+	return a
+}
+
+func (a AdaptiveRuns) validate() error {
+	if a.TargetPrecision < 0 || a.TargetPrecision >= 1 {
+		return fmt.Errorf("target precision must be between 0 and 1: %g", a.TargetPrecision)
+	}
+	if a.MaxRuns < a.MinRuns {
+		return fmt.Errorf("max runs %d is below min runs %d", a.MaxRuns, a.MinRuns)
+	}
+	if a.TimeBudget < 0 {
+		return fmt.Errorf("time budget must not be negative: %s", a.TimeBudget)
+	}
+	return nil
+}
+
+func runsComplete(config BenchmarkConfig, durations []time.Duration, elapsed time.Duration) bool {
+	if !config.Adaptive.Enabled() {
+		return len(durations) >= config.Runs
+	}
+	
+	adaptive := config.Adaptive
+	switch n := len(durations); {
+	case n >= adaptive.MaxRuns:
+		return true
+	case n > 0 && adaptive.TimeBudget > 0 && elapsed >= adaptive.TimeBudget:
+		return true
+	case n < max(adaptive.MinRuns, 2):
+		return false
+	default:
+		return relativeHalfWidth(durations) <= adaptive.TargetPrecision
+	}
+}
+
+func batchSize(duration time.Duration) int {
+	if duration >= minSampleDuration {
+		return 1
+	}
+	if duration <= 0 {
+		return maxBatch
+	}
+	return min(int(minSampleDuration/duration)+1, maxBatch)
+}
+
+func relativeHalfWidth(durations []time.Duration) float64 {
+	n := len(durations)
+	if n < 2 {
+		return 0
+	}
+	
+	values := make([]float64, n)
+	for i, d := range durations {
+		values[i] = float64(d)
+	}
+	mean, stdDev := meanStdDev(values)
+	if mean == 0 {
+		return 0
+	}
+	return tQuantile975(n-1) * stdDev / math.Sqrt(float64(n)) / mean
+}
+
+var tTable975 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+func tQuantile975(df int) float64 {
+	switch {
+	case df < 1:
+		return math.Inf(1)
+	case df <= len(tTable975):
+		return tTable975[df-1]
+	case df <= 60:
+		return 2.000
+	case df <= 120:
+		return 1.980
+	default:
+		return 1.960
+	}
+}
@@ -3,188 +3,760 @@ package benchmark
 import (
 	"algorithm-benchmark/algorithms"
 	"algorithm-benchmark/data"
+	"context"
+	"errors"
 	"fmt"
-	"runtime"
+	"math/rand"
+	"runtime/debug"
+	"slices"
+	"sync"
 	"time"
 )
 
+const DefaultWarmup = 0
+
 type BenchmarkResult struct {
-	Algorithm     string
-	ArrayType     string
-	Size          int
-	Duration      time.Duration
-	MemoryBefore  uint64
-	MemoryAfter   uint64
-	MemoryUsed    uint64
-	Runs          int
-	MeanDuration  time.Duration
-	StdDeviation  time.Duration
-	MinDuration   time.Duration
-	MaxDuration   time.Duration
+	Algorithm         string              `json:"algorithm"`
+	ArrayType         string              `json:"arrayType"`
+	ArrayParams       string              `json:"arrayParams,omitempty"`
+	ElementType       string              `json:"elementType"`
+	Dataset           string              `json:"dataset,omitempty"`
+	Checksum          string              `json:"checksum,omitempty"`
+	Size              int                 `json:"size"`
+	Seed              int64               `json:"seed"`
+	PivotStrategy     string              `json:"pivotStrategy,omitempty"`
+	PartitionScheme   string              `json:"partitionScheme,omitempty"`
+	Duration          time.Duration       `json:"duration"`
+	LayoutDuration    time.Duration       `json:"layoutDuration,omitempty"`
+	Queries           int                 `json:"queries,omitempty"`
+	HitRatio          float64             `json:"hitRatio"`
+	QueryDistribution string              `json:"queryDistribution,omitempty"`
+	QueryLatency      time.Duration       `json:"queryLatency,omitempty"`
+	Throughput        float64             `json:"throughput,omitempty"`
+	Operations        *algorithms.Counter `json:"operations,omitempty"`
+	MemoryBefore      uint64              `json:"memoryBefore"`
+	MemoryAfter       uint64              `json:"memoryAfter"`
+	MemoryUsed        uint64              `json:"memoryUsed"`
+	BytesAllocated    uint64              `json:"bytesAllocated"`
+	Allocations       uint64              `json:"allocations"`
+	BytesPerOp        uint64              `json:"bytesPerOp"`
+	AllocsPerOp       uint64              `json:"allocsPerOp"`
+	PeakHeap          uint64              `json:"peakHeap"`
+	PeakHeapMode      string              `json:"peakHeapMode"`
+	Runs              int                 `json:"runs"`
+	Batch             int                 `json:"batch,omitempty"`
+	Precision         float64             `json:"precision"`
+	TargetPrecision   float64             `json:"targetPrecision,omitempty"`
+	Warmup            int                 `json:"warmup,omitempty"`
+	ReuseInput        bool                `json:"reuseInput,omitempty"`
+	MeanDuration      time.Duration       `json:"meanDuration"`
+	StdDeviation      time.Duration       `json:"stdDeviation"`
+	MinDuration       time.Duration       `json:"minDuration"`
+	MaxDuration       time.Duration       `json:"maxDuration"`
+	MedianDuration    time.Duration       `json:"medianDuration"`
+	P90Duration       time.Duration       `json:"p90Duration"`
+	P95Duration       time.Duration       `json:"p95Duration"`
+	P99Duration       time.Duration       `json:"p99Duration"`
+	MAD               time.Duration       `json:"mad"`
+	CV                float64             `json:"cv"`
+	CILower           time.Duration       `json:"ciLower"`
+	CIUpper           time.Duration       `json:"ciUpper"`
+	Stable            *bool               `json:"stable,omitempty"`
+	Plan              string              `json:"plan,omitempty"`
+	PlanChecksum      string              `json:"planChecksum,omitempty"`
+	Status            string              `json:"status"`
+	Detail            string              `json:"detail,omitempty"`
+	Stack             string              `json:"stack,omitempty"`
 }
 
 type BenchmarkConfig struct {
-	Algorithm string
-	ArrayType data.ArrayType
-	Size      int
-	Runs      int
-	Target    int
+	Algorithm   string
+	ArrayType   data.ArrayType
+	ArrayParams data.ArrayParams
+	Size        int
+	Runs        int
+	Warmup      int
+	ReuseInput  bool
+	Adaptive    AdaptiveRuns
+	Target      int
+	Pivot       algorithms.PivotStrategy
+	Partition   algorithms.PartitionScheme
+	Workload    SearchWorkload
+	Seed        int64
+	Dataset     *data.Dataset
+	ElementType data.ElementType
+	Timeout     time.Duration
+	CellBudget  time.Duration
+	MaxSize     int
+	SizeCaps    map[string]int
+	Plan        *Plan
+	
+	CountOperations bool
+	MeasurePeakHeap bool
+}
+
+type SearchWorkload struct {
+	Queries      int
+	HitRatio     float64
+	Distribution data.QueryDistribution
+}
+
+func (config BenchmarkConfig) cell(algorithm string, arrayType data.ArrayType, size int) BenchmarkConfig {
+	config.Algorithm = algorithm
+	config.ArrayType = arrayType
+	config.Size = size
+	config.MaxSize = config.SizeCaps[algorithm]
+	return config
+}
+
+func DefaultSearchWorkload() SearchWorkload {
+	return SearchWorkload{
+		Queries:      1000,
+		HitRatio:     1,
+		Distribution: data.UniformQueries,
+	}
 }
 
 type BenchmarkSuite struct {
-	results []BenchmarkResult
+	mu        sync.Mutex
+	results   []BenchmarkResult
+	plan      *Plan
+	stability map[string]bool
+	samples   map[sampleKey]map[int]time.Duration
 }
 
 func NewBenchmarkSuite() *BenchmarkSuite {
 	return &BenchmarkSuite{
-		results: make([]BenchmarkResult, 0),
+		results:   make([]BenchmarkResult, 0),
+		stability: make(map[string]bool),
+		samples:   make(map[sampleKey]map[int]time.Duration),
 	}
 }
 
 func (bs *BenchmarkSuite) RunBenchmark(config BenchmarkConfig) (BenchmarkResult, error) {
+	return bs.RunBenchmarkContext(context.Background(), config)
+}
+
+func (bs *BenchmarkSuite) RunBenchmarkContext(ctx context.Context, config BenchmarkConfig) (result BenchmarkResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: string(debug.Stack())}
+		}
+	}()
+	return bs.runBenchmark(ctx, config)
+}
+
+func (bs *BenchmarkSuite) runBenchmark(ctx context.Context, config BenchmarkConfig) (BenchmarkResult, error) {
+	algorithm, ok := algorithms.Get(config.Algorithm)
+	if !ok {
+		return BenchmarkResult{}, fmt.Errorf("unknown algorithm: %s", config.Algorithm)
+	}
+	
+	sortFunc := algorithm.Sort
+	options := algorithms.SortOptions{
+		Pivot:     config.Pivot,
+		Partition: config.Partition,
+	}
+	if algorithm.SortWithOptions != nil {
+		sortFunc = func(arr []int) []int {
+			return algorithm.SortWithOptions(arr, options)
+		}
+	} else if options.Pivot != algorithms.PivotLast || options.Partition != algorithms.PartitionLomuto {
+		return BenchmarkResult{}, fmt.Errorf("algorithm %s does not support pivot or partition options", config.Algorithm)
+	} else if algorithm.Interruptible != nil {
+		sortFunc = func(arr []int) []int {
+			return algorithm.Interruptible(arr, options)
+		}
+	}
+	
+	countedRun := countedRunner(algorithm)
+	if config.CountOperations && countedRun == nil {
+		return BenchmarkResult{}, fmt.Errorf("algorithm %s does not support operation counting", config.Algorithm)
+	}
+	
+	if config.ElementType != data.IntElements {
+		if !SupportsElementType(algorithm, config.ElementType) {
+			return BenchmarkResult{}, fmt.Errorf("algorithm %s does not support %s elements", config.Algorithm, data.GetElementTypeName(config.ElementType))
+		}
+		if config.CountOperations {
+			return BenchmarkResult{}, fmt.Errorf("operation counting is only supported for int elements")
+		}
+	}
+	
+	if config.Warmup < 0 {
+		return BenchmarkResult{}, fmt.Errorf("warm-up iterations must not be negative: %d", config.Warmup)
+	}
+	if config.Timeout < 0 {
+		return BenchmarkResult{}, fmt.Errorf("timeout must not be negative: %s", config.Timeout)
+	}
+	if config.Adaptive.Enabled() {
+		config.Adaptive = config.Adaptive.withDefaults()
+	}
+	if err := config.Adaptive.validate(); err != nil {
+		return BenchmarkResult{}, err
+	}
+	
+	if config.Dataset != nil {
+		config.Size = len(config.Dataset.Values)
+	}
+	if config.Seed == 0 {
+		config.Seed = NewSeed()
+	}
+	seeds := data.NewSeededRand(config.Seed)
+	
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+	interrupt, release := algorithms.WatchContext(ctx)
+	defer release()
+	options.Interrupt = interrupt
+	
+	var input []int
+	var inputSeed int64
+	if config.ReuseInput {
+		inputSeed = seeds.Int63()
+		if config.Dataset == nil {
+			generated, err := GenerateInput(config.Algorithm, config.Size, config.ArrayType, config.ArrayParams, options, data.NewSeededRand(inputSeed))
+			if err != nil {
+				return BenchmarkResult{}, err
+			}
+			input = generated
+		}
+	}
+	
 	var durations []time.Duration
-	var memoryUsages []uint64
+	var layoutDurations []time.Duration
+	var memory []MemoryStats
+	var operations algorithms.Counter
+	var hits, searches int
+	peakMeasured := !config.MeasurePeakHeap
 	
-	for run := 0; run < config.Runs; run++ {
-		arr := data.GenerateArray(config.Size, config.ArrayType)
+	batch := 1
+	calibrating := config.Adaptive.Enabled()
+	recalibrate := func(duration time.Duration) bool {
+		if !calibrating {
+			return false
+		}
+		if duration*time.Duration(batch) < minSampleDuration && batch < maxBatch {
+			batch = min(max(2*batch, batchSize(duration)), maxBatch)
+			return true
+		}
+		calibrating = false
+		return false
+	}
+	
+	start := time.Now()
+	stopped := false
+	warmups := 0
+	for warmups < config.Warmup || !runsComplete(config, durations, time.Since(start)) {
+		if ctx.Err() != nil {
+			stopped = true
+			break
+		}
 		
-		var memStatsBefore, memStatsAfter runtime.MemStats
-		runtime.GC()
-		runtime.ReadMemStats(&memStatsBefore)
+		warmup := warmups < config.Warmup
+		rng := data.NewSeededRand(seeds.Int63())
+		pivotSeed := rng.Int63()
+		options.Rand = data.NewSeededRand(pivotSeed)
+		if config.ReuseInput {
+			rng = data.NewSeededRand(inputSeed)
+		}
+		
+		var arr []int
+		if config.Dataset != nil {
+			arr = append([]int(nil), config.Dataset.Values...)
+		} else if input != nil {
+			arr = append([]int(nil), input...)
+		} else {
+			generated, err := GenerateInput(config.Algorithm, config.Size, config.ArrayType, config.ArrayParams, options, rng)
+			if err != nil {
+				return BenchmarkResult{}, err
+			}
+			arr = generated
+		}
+		
+		if config.ElementType != data.IntElements {
+			measurePeak := !warmup && !peakMeasured
+			duration, memoryStats, err := runElementSort(algorithm.Elements, config.ElementType, arr, config.ArrayParams, options, rng, batch, measurePeak)
+			if errors.Is(err, algorithms.ErrInterrupted) || ctx.Err() != nil {
+				stopped = true
+				break
+			}
+			if err != nil {
+				return BenchmarkResult{}, fmt.Errorf("%w for %s", err, config.Algorithm)
+			}
+			if recalibrate(duration) {
+				continue
+			}
+			if warmup {
+				warmups++
+			} else {
+				durations = append(durations, duration)
+				memory = append(memory, memoryStats)
+			}
+			peakMeasured = peakMeasured || measurePeak
+			continue
+		}
 		
-		start := time.Now()
+		var queries, found, order []int
+		var query func(target int) int
+		searchInput := arr
+		if algorithm.Kind == algorithms.Search {
+			queries = []int{config.Target}
+			if config.Workload.Queries > 0 {
+				queries = data.GenerateQueriesWithRand(arr, config.Workload.Queries, config.Workload.HitRatio, config.Workload.Distribution, rng)
+			}
+			found = make([]int, len(queries))
+			
+			if algorithm.Layout != nil || (algorithm.Metadata.RequiresSorted && !data.IsSorted(arr)) {
+				layoutStart := time.Now()
+				if !data.IsSorted(arr) {
+					searchInput, order = algorithms.SortedWithIndex(arr)
+				}
+				query = searchQuery(algorithm, searchInput, nil)
+				if !warmup {
+					layoutDurations = append(layoutDurations, time.Since(layoutStart))
+				}
+			} else {
+				query = searchQuery(algorithm, searchInput, nil)
+			}
+		}
 		
 		var result interface{}
+		var selected int
+		var selectedOK bool
 		var err error
 		
-		switch config.Algorithm {
-		case "linear_search":
-			result = algorithms.LinearSearch(arr, config.Target)
-		case "binary_search":
-			if config.ArrayType == data.Sorted {
-				result = algorithms.BinarySearchSorted(arr, config.Target)
-			} else {
-				result = algorithms.BinarySearchUnsorted(arr, config.Target)
+		run := func() {
+			switch algorithm.Kind {
+			case algorithms.Search:
+				for i, target := range queries {
+					found[i] = query(target)
+				}
+			case algorithms.Sort:
+				result = sortFunc(arr)
+			case algorithms.Select:
+				selected, selectedOK = algorithm.Select(arr, config.Target)
 			}
-		case "bubble_sort":
-			result = algorithms.BubbleSort(arr)
-		case "insertion_sort":
-			result = algorithms.InsertionSort(arr)
-		case "merge_sort":
-			result = algorithms.MergeSort(arr)
-		case "quick_sort":
-			result = algorithms.QuickSort(arr)
-		case "heap_sort":
-			result = algorithms.HeapSort(arr)
-		case "native_sort":
-			result = algorithms.NativeSort(arr)
-		default:
-			return BenchmarkResult{}, fmt.Errorf("unknown algorithm: %s", config.Algorithm)
 		}
+		duration, memoryStats := measure(func() {
+			err = interrupt.Run(func() {
+				for b := 0; b < batch && !interrupt.Stopped(); b++ {
+					run()
+				}
+			})
+		})
+		duration /= time.Duration(batch)
 		
-		duration := time.Since(start)
+		if err != nil || ctx.Err() != nil {
+			stopped = true
+			break
+		}
+		if recalibrate(duration) {
+			continue
+		}
 		
-		runtime.ReadMemStats(&memStatsAfter)
-		memoryUsed := memStatsAfter.Alloc - memStatsBefore.Alloc
+		if !warmup && !peakMeasured {
+			err := interrupt.Run(func() {
+				memoryStats.PeakHeap = measurePeakHeap(run)
+			})
+			if err != nil || ctx.Err() != nil {
+				stopped = true
+				break
+			}
+			peakMeasured = true
+		}
 		
-		if err != nil {
-			return BenchmarkResult{}, err
+		if config.CountOperations && !warmup {
+			var counter algorithms.Counter
+			counted := options
+			counted.Rand = data.NewSeededRand(pivotSeed)
+			err := interrupt.Run(func() {
+				countedRun(searchInput, queries, config.Target, counted, &counter)
+			})
+			if err != nil || ctx.Err() != nil {
+				stopped = true
+				break
+			}
+			operations.Add(counter)
+		}
+		
+		if warmup {
+			warmups++
+		} else {
+			durations = append(durations, duration)
+			memory = append(memory, memoryStats)
 		}
 		
-		durations = append(durations, duration)
-		memoryUsages = append(memoryUsages, memoryUsed)
+		if algorithm.Kind == algorithms.Search {
+			if order != nil {
+				for i, index := range found {
+					if index != -1 {
+						found[i] = order[index]
+					}
+				}
+			}
+			
+			if !data.VerifySearches(arr, queries, found) {
+				return BenchmarkResult{}, fmt.Errorf("search %w for %s", ErrVerificationFailed, config.Algorithm)
+			}
+			if !warmup {
+				for _, index := range found {
+					if index != -1 {
+						hits++
+					}
+				}
+				searches += len(found)
+			}
+		}
 		
-		if config.Algorithm != "linear_search" && config.Algorithm != "binary_search" {
+		if algorithm.Kind == algorithms.Sort {
 			if sortedResult, ok := result.([]int); ok {
 				if !data.VerifySorting(arr, sortedResult) {
-					return BenchmarkResult{}, fmt.Errorf("sorting verification failed for %s", config.Algorithm)
+					return BenchmarkResult{}, fmt.Errorf("sorting %w for %s", ErrVerificationFailed, config.Algorithm)
 				}
 			}
 		}
+		
+		if algorithm.Kind == algorithms.Select {
+			if !data.VerifySelection(arr, config.Target, selected, selectedOK) {
+				return BenchmarkResult{}, fmt.Errorf("selection %w for %s", ErrVerificationFailed, config.Algorithm)
+			}
+		}
 	}
 	
-	meanDuration := calculateMean(durations)
-	stdDeviation := calculateStdDeviation(durations, meanDuration)
-	minDuration := calculateMin(durations)
-	maxDuration := calculateMax(durations)
+	stats := ComputeStatistics(durations, data.NewSeededRand(config.Seed))
 	
-	meanMemory := calculateMeanUint64(memoryUsages)
+	totalMemory := summarizeMemory(memory)
+	var bytesPerOp, allocsPerOp uint64
+	if ops := uint64(len(memory) * batch); ops > 0 {
+		bytesPerOp = totalMemory.BytesAllocated / ops
+		allocsPerOp = totalMemory.Allocations / ops
+	}
 	
 	benchmarkResult := BenchmarkResult{
-		Algorithm:     config.Algorithm,
-		ArrayType:     data.GetArrayTypeName(config.ArrayType),
-		Size:          config.Size,
-		Duration:      meanDuration,
-		MemoryUsed:    meanMemory,
-		Runs:          config.Runs,
-		MeanDuration:  meanDuration,
-		StdDeviation:  stdDeviation,
-		MinDuration:   minDuration,
-		MaxDuration:   maxDuration,
-	}
-	
-	bs.results = append(bs.results, benchmarkResult)
+		Algorithm:       config.Algorithm,
+		ArrayType:       data.GetArrayTypeName(config.ArrayType),
+		ArrayParams:     joinParams(data.FormatArrayParams(config.ArrayType, config.Size, config.ArrayParams), data.FormatElementParams(config.ElementType, config.Size, config.ArrayParams)),
+		ElementType:     data.GetElementTypeName(config.ElementType),
+		Size:            config.Size,
+		Seed:            config.Seed,
+		Duration:        stats.Mean,
+		MemoryUsed:      bytesPerOp,
+		BytesAllocated:  totalMemory.BytesAllocated,
+		Allocations:     totalMemory.Allocations,
+		BytesPerOp:      bytesPerOp,
+		AllocsPerOp:     allocsPerOp,
+		PeakHeap:        totalMemory.PeakHeap,
+		PeakHeapMode:    PeakHeapOff,
+		Runs:            len(durations),
+		Batch:           batch,
+		Precision:       relativeHalfWidth(durations),
+		TargetPrecision: config.Adaptive.TargetPrecision,
+		Warmup:          config.Warmup,
+		ReuseInput:      config.ReuseInput,
+		MeanDuration:    stats.Mean,
+		StdDeviation:    stats.StdDeviation,
+		MinDuration:     stats.Min,
+		MaxDuration:     stats.Max,
+		MedianDuration:  stats.Median,
+		P90Duration:     stats.P90,
+		P95Duration:     stats.P95,
+		P99Duration:     stats.P99,
+		MAD:             stats.MAD,
+		CV:              stats.CoefficientOfVariation,
+		CILower:         stats.CILower,
+		CIUpper:         stats.CIUpper,
+		Status:          StatusOK,
+	}
+	if stopped {
+		benchmarkResult.Status = StatusTimeout
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			benchmarkResult.Status = StatusCancelled
+		}
+	}
+	
+	if config.Dataset != nil {
+		benchmarkResult.ArrayType = "Dataset"
+		benchmarkResult.ArrayParams = data.FormatElementParams(config.ElementType, config.Size, config.ArrayParams)
+		benchmarkResult.Dataset = config.Dataset.Name
+		benchmarkResult.Checksum = config.Dataset.Checksum
+	}
+	if config.Plan != nil {
+		benchmarkResult.Plan = config.Plan.Name
+		benchmarkResult.PlanChecksum = config.Plan.Checksum
+	}
+	
+	if config.MeasurePeakHeap {
+		benchmarkResult.PeakHeapMode = PeakHeapUntimed
+	}
+	
+	if len(layoutDurations) > 0 {
+		benchmarkResult.LayoutDuration = calculateMean(layoutDurations)
+	}
+	
+	if config.CountOperations && len(durations) > 0 {
+		runs := uint64(len(durations))
+		benchmarkResult.Operations = &algorithms.Counter{
+			Comparisons: operations.Comparisons / runs,
+			Swaps:       operations.Swaps / runs,
+			Reads:       operations.Reads / runs,
+			Writes:      operations.Writes / runs,
+		}
+	}
+	
+	if algorithm.Kind == algorithms.Search {
+		queries := 1
+		if config.Workload.Queries > 0 {
+			queries = config.Workload.Queries
+			benchmarkResult.HitRatio = config.Workload.HitRatio
+			benchmarkResult.QueryDistribution = data.GetQueryDistributionName(config.Workload.Distribution)
+		} else if searches > 0 {
+			benchmarkResult.HitRatio = float64(hits) / float64(searches)
+		}
+		
+		benchmarkResult.Queries = queries
+		benchmarkResult.QueryLatency = stats.Mean / time.Duration(queries)
+		if stats.Mean > 0 {
+			benchmarkResult.Throughput = float64(queries) / stats.Mean.Seconds()
+		}
+	}
+	
+	if algorithm.SortWithOptions != nil {
+		benchmarkResult.PivotStrategy = algorithms.GetPivotStrategyName(config.Pivot)
+		benchmarkResult.PartitionScheme = algorithms.GetPartitionSchemeName(config.Partition)
+	}
+	
+	if algorithm.Kind == algorithms.Sort && algorithm.Elements.Record != nil && !stopped {
+		stable, err := bs.verifyStability(config.Algorithm, options, config.Seed)
+		if err != nil {
+			benchmarkResult.Status = statusOf(err)
+			benchmarkResult.Detail = err.Error()
+		} else {
+			benchmarkResult.Stable = &stable
+		}
+	}
+	
+	bs.addResult(benchmarkResult)
 	return benchmarkResult, nil
 }
 
-func (bs *BenchmarkSuite) RunSearchBenchmarks(sizes []int, runs int) error {
-	searchAlgorithms := []string{"linear_search", "binary_search"}
+func (bs *BenchmarkSuite) verifyStability(algorithm string, options algorithms.SortOptions, seed int64) (bool, error) {
+	key := fmt.Sprintf("%s/%d/%d", algorithm, options.Pivot, options.Partition)
+	bs.mu.Lock()
+	stable, ok := bs.stability[key]
+	bs.mu.Unlock()
+	if ok {
+		return stable, nil
+	}
+	
+	stable, err := CheckStability(algorithm, options, data.NewSeededRand(seed))
+	if err != nil {
+		return false, err
+	}
+	
+	bs.mu.Lock()
+	bs.stability[key] = stable
+	bs.mu.Unlock()
+	return stable, nil
+}
+
+func searchQuery(algorithm algorithms.Algorithm, arr []int, counter *algorithms.Counter) func(target int) int {
+	if algorithm.Layout != nil {
+		return algorithm.Layout(arr, counter)
+	}
+	if counter != nil {
+		return func(target int) int {
+			return algorithm.CountedSearch(arr, target, counter)
+		}
+	}
+	return func(target int) int {
+		return algorithm.Search(arr, target)
+	}
+}
+
+func GenerateInput(algorithm string, size int, arrayType data.ArrayType, params data.ArrayParams, options algorithms.SortOptions, rng *rand.Rand) ([]int, error) {
+	if arrayType == data.AntiQuicksort {
+		options.Rand = data.NewSeededRand(rng.Int63())
+		options.Counter = nil
+		target, err := algorithms.ComparisonSort(algorithm, options)
+		if err != nil {
+			return nil, fmt.Errorf("array type %s needs a comparison sort: %v", data.GetArrayTypeKey(arrayType), err)
+		}
+		return data.AntiQuicksortArray(size, data.CompareSort(target)), nil
+	}
+	return data.GenerateArrayWithRand(size, arrayType, params, rng), nil
+}
+
+func NewSeed() int64 {
+	for {
+		if seed := rand.Int63(); seed != 0 {
+			return seed
+		}
+	}
+}
+
+func countedRunner(algorithm algorithms.Algorithm) func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+	switch algorithm.Kind {
+	case algorithms.Search:
+		if algorithm.Layout == nil && algorithm.CountedSearch == nil {
+			return nil
+		}
+		return func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+			query := searchQuery(algorithm, arr, counter)
+			for _, q := range queries {
+				if options.Interrupt.Stopped() {
+					return
+				}
+				query(q)
+			}
+		}
+	case algorithms.Sort:
+		if algorithm.SortWithOptions != nil {
+			return func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+				options.Counter = counter
+				algorithm.SortWithOptions(arr, options)
+			}
+		}
+		if algorithm.Interruptible != nil {
+			return func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+				options.Counter = counter
+				algorithm.Interruptible(arr, options)
+			}
+		}
+		if algorithm.CountedSort == nil {
+			return nil
+		}
+		return func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+			algorithm.CountedSort(arr, counter)
+		}
+	case algorithms.Select:
+		if algorithm.CountedSelect == nil {
+			return nil
+		}
+		return func(arr, queries []int, target int, options algorithms.SortOptions, counter *algorithms.Counter) {
+			algorithm.CountedSelect(arr, target, counter)
+		}
+	}
+	return nil
+}
+
+func (bs *BenchmarkSuite) RunSearchBenchmarks(ctx context.Context, sizes []int, base BenchmarkConfig) error {
+	searchAlgorithms := algorithms.Names(algorithms.Search)
 	arrayTypes := data.GetAllArrayTypes()
 	
 	for _, algorithm := range searchAlgorithms {
 		for _, arrayType := range arrayTypes {
 			for _, size := range sizes {
-				config := BenchmarkConfig{
-					Algorithm: algorithm,
-					ArrayType: arrayType,
-					Size:      size,
-					Runs:      runs,
-					Target:    size / 2,
-				}
+				config := base.cell(algorithm, arrayType, size)
+				config.Target = size / 2
+				config.ElementType = data.IntElements
 				
-				_, err := bs.RunBenchmark(config)
-				if err != nil {
-					return fmt.Errorf("benchmark failed for %s: %v", algorithm, err)
-				}
+				bs.RunCell(ctx, config)
 			}
 		}
 	}
 	
-	return nil
+	return ctx.Err()
 }
 
-func (bs *BenchmarkSuite) RunSortBenchmarks(sizes []int, runs int) error {
-	sortAlgorithms := []string{"bubble_sort", "insertion_sort", "merge_sort", "quick_sort", "heap_sort", "native_sort"}
+func (bs *BenchmarkSuite) RunSortBenchmarks(ctx context.Context, sizes []int, base BenchmarkConfig) error {
 	arrayTypes := data.GetAllArrayTypes()
 	
-	for _, algorithm := range sortAlgorithms {
+	for _, algorithm := range algorithms.ByKind(algorithms.Sort) {
+		if !SupportsElementType(algorithm, base.ElementType) {
+			continue
+		}
+		
 		for _, arrayType := range arrayTypes {
 			for _, size := range sizes {
-				config := BenchmarkConfig{
-					Algorithm: algorithm,
-					ArrayType: arrayType,
-					Size:      size,
-					Runs:      runs,
-					Target:    0,
-				}
+				config := base.cell(algorithm.Name, arrayType, size)
+				config.Target = 0
 				
-				_, err := bs.RunBenchmark(config)
-				if err != nil {
-					return fmt.Errorf("benchmark failed for %s: %v", algorithm, err)
+				bs.RunCell(ctx, config)
+			}
+		}
+	}
+	
+	return ctx.Err()
+}
+
+func (bs *BenchmarkSuite) RunSortVariantBenchmarks(ctx context.Context, sizes []int, base BenchmarkConfig) error {
+	arrayTypes := data.GetAllArrayTypes()
+	
+	for _, algorithm := range algorithms.ByKind(algorithms.Sort) {
+		if algorithm.SortWithOptions == nil || !SupportsElementType(algorithm, base.ElementType) {
+			continue
+		}
+		
+		for _, pivot := range algorithms.GetAllPivotStrategies() {
+			for _, partition := range algorithms.GetAllPartitionSchemes() {
+				for _, arrayType := range arrayTypes {
+					for _, size := range sizes {
+						config := base.cell(algorithm.Name, arrayType, size)
+						config.Target = 0
+						config.Pivot = pivot
+						config.Partition = partition
+						
+						bs.RunCell(ctx, config)
+					}
 				}
 			}
 		}
 	}
 	
-	return nil
+	return ctx.Err()
+}
+
+func (bs *BenchmarkSuite) RunSelectBenchmarks(ctx context.Context, sizes []int, base BenchmarkConfig) error {
+	selectAlgorithms := algorithms.Names(algorithms.Select)
+	arrayTypes := data.GetAllArrayTypes()
+	
+	for _, algorithm := range selectAlgorithms {
+		for _, arrayType := range arrayTypes {
+			for _, size := range sizes {
+				config := base.cell(algorithm, arrayType, size)
+				config.Target = size / 2
+				config.ElementType = data.IntElements
+				
+				bs.RunCell(ctx, config)
+			}
+		}
+	}
+	
+	return ctx.Err()
+}
+
+func DefaultSizes() []int {
+	return []int{1000, 10000, 100000, 1000000}
+}
+
+func (bs *BenchmarkSuite) addResult(result BenchmarkResult) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.results = append(bs.results, result)
 }
 
 func (bs *BenchmarkSuite) GetResults() []BenchmarkResult {
-	return bs.results
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return slices.Clone(bs.results)
+}
+
+func (bs *BenchmarkSuite) GetPlan() *Plan {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.plan
 }
 
 func (bs *BenchmarkSuite) ClearResults() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
 	bs.results = make([]BenchmarkResult, 0)
+	bs.plan = nil
+	bs.samples = make(map[sampleKey]map[int]time.Duration)
 }
 
 func calculateMean(durations []time.Duration) time.Duration {
@@ -198,58 +770,3 @@ func calculateMean(durations []time.Duration) time.Duration {
 	}
 	return total / time.Duration(len(durations))
 }
-
-func calculateStdDeviation(durations []time.Duration, mean time.Duration) time.Duration {
-	if len(durations) <= 1 {
-		return 0
-	}
-	
-	var sumSquares time.Duration
-	for _, d := range durations {
-		diff := d - mean
-		sumSquares += diff * diff
-	}
-	
-	variance := sumSquares / time.Duration(len(durations)-1)
-	return time.Duration(int64(variance) / int64(time.Microsecond)) * time.Microsecond
-}
-
-func calculateMin(durations []time.Duration) time.Duration {
-	if len(durations) == 0 {
-		return 0
-	}
-	
-	min := durations[0]
-	for _, d := range durations {
-		if d < min {
-			min = d
-		}
-	}
-	return min
-}
-
-func calculateMax(durations []time.Duration) time.Duration {
-	if len(durations) == 0 {
-		return 0
-	}
-	
-	max := durations[0]
-	for _, d := range durations {
-		if d > max {
-			max = d
-		}
-	}
-	return max
-}
-
-func calculateMeanUint64(values []uint64) uint64 {
-	if len(values) == 0 {
-		return 0
-	}
-	
-	var total uint64
-	for _, v := range values {
-		total += v
-	}
-	return total / uint64(len(values))
-}
@@ -1,8 +1,16 @@
 package benchmark
 
 import (
+	"algorithm-benchmark/algorithms"
 	"algorithm-benchmark/data"
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestBenchmarkSuite(t *testing.T) {
@@ -44,7 +52,7 @@ func TestSearchBenchmarks(t *testing.T) {
 	sizes := []int{100, 1000}
 	runs := 2
 	
-	err := suite.RunSearchBenchmarks(sizes, runs)
+	err := suite.RunSearchBenchmarks(context.Background(), sizes, BenchmarkConfig{Runs: runs, Workload: DefaultSearchWorkload()})
 	if err != nil {
 		t.Fatalf("Search benchmarks failed: %v", err)
 	}
@@ -74,7 +82,7 @@ func TestSortBenchmarks(t *testing.T) {
 	sizes := []int{100, 1000}
 	runs := 2
 	
-	err := suite.RunSortBenchmarks(sizes, runs)
+	err := suite.RunSortBenchmarks(context.Background(), sizes, BenchmarkConfig{Runs: runs})
 	if err != nil {
 		t.Fatalf("Sort benchmarks failed: %v", err)
 	}
@@ -98,6 +106,1024 @@ func TestSortBenchmarks(t *testing.T) {
 	}
 }
 
+func TestSelectBenchmarks(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	if err := suite.RunSelectBenchmarks(context.Background(), []int{100, 1000}, BenchmarkConfig{Runs: 2}); err != nil {
+		t.Fatalf("Select benchmarks failed: %v", err)
+	}
+	
+	if len(suite.GetResults()) == 0 {
+		t.Error("Expected results from select benchmarks")
+	}
+}
+
+func TestLayoutSearchBenchmark(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	for _, arrayType := range []data.ArrayType{data.Sorted, data.Random} {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm: "eytzinger_search",
+			ArrayType: arrayType,
+			Size:      1000,
+			Runs:      2,
+			Target:    500,
+		})
+		if err != nil {
+			t.Fatalf("Layout search benchmark failed: %v", err)
+		}
+		
+		if result.LayoutDuration <= 0 {
+			t.Errorf("Expected positive layout duration for %s", data.GetArrayTypeName(arrayType))
+		}
+	}
+	
+	result, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "binary_search",
+		ArrayType: data.Sorted,
+		Size:      1000,
+		Runs:      1,
+		Target:    500,
+	})
+	if err != nil {
+		t.Fatalf("Binary search benchmark failed: %v", err)
+	}
+	if result.LayoutDuration != 0 {
+		t.Errorf("Expected no layout duration for binary_search, got %v", result.LayoutDuration)
+	}
+	if result.HitRatio != 1 {
+		t.Errorf("Expected a hit ratio of 1 for a target in the array, got %v", result.HitRatio)
+	}
+	
+	result, err = suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "binary_search",
+		ArrayType: data.Sorted,
+		Size:      1000,
+		Runs:      1,
+		Target:    -1,
+	})
+	if err != nil {
+		t.Fatalf("Binary search benchmark failed: %v", err)
+	}
+	if result.HitRatio != 0 {
+		t.Errorf("Expected a hit ratio of 0 for a target missing from the array, got %v", result.HitRatio)
+	}
+}
+
+func TestSearchWorkload(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	for _, distribution := range data.GetAllQueryDistributions() {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm: "binary_search",
+			ArrayType: data.Random,
+			Size:      1000,
+			Runs:      2,
+			Workload: SearchWorkload{
+				Queries:      200,
+				HitRatio:     0.5,
+				Distribution: distribution,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Search workload benchmark failed: %v", err)
+		}
+		
+		if result.Queries != 200 {
+			t.Errorf("Expected 200 queries, got %d", result.Queries)
+		}
+		if result.QueryDistribution != data.GetQueryDistributionName(distribution) {
+			t.Errorf("Expected distribution %s, got %s", data.GetQueryDistributionName(distribution), result.QueryDistribution)
+		}
+		if result.QueryLatency <= 0 || result.Throughput <= 0 {
+			t.Errorf("Expected positive latency and throughput, got %v and %f", result.QueryLatency, result.Throughput)
+		}
+	}
+}
+
+func TestSearchVerification(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	for _, name := range algorithms.Names(algorithms.Search) {
+		for _, target := range []int{0, 7, -1} {
+			_, err := suite.RunBenchmark(BenchmarkConfig{
+				Algorithm: name,
+				ArrayType: data.Random,
+				Size:      50,
+				Runs:      3,
+				Target:    target,
+			})
+			if err != nil {
+				t.Errorf("%s with target %d: %v", name, target, err)
+			}
+		}
+	}
+}
+
+func TestOperationCounting(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	result, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm:       "bubble_sort",
+		ArrayType:       data.ReverseSorted,
+		Size:            50,
+		Runs:            2,
+		CountOperations: true,
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if result.Operations == nil {
+		t.Fatal("Expected operation counts when counting is enabled")
+	}
+	if result.Operations.Comparisons != 50*49/2 || result.Operations.Swaps != 50*49/2 {
+		t.Errorf("Expected %d comparisons and swaps, got %+v", 50*49/2, *result.Operations)
+	}
+	
+	result, err = suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "bubble_sort",
+		ArrayType: data.ReverseSorted,
+		Size:      50,
+		Runs:      1,
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if result.Operations != nil {
+		t.Error("Expected no operation counts when counting is disabled")
+	}
+	
+	suite.ClearResults()
+	base := BenchmarkConfig{Runs: 1, Workload: DefaultSearchWorkload(), CountOperations: true}
+	if err := suite.RunSearchBenchmarks(context.Background(), []int{100}, base); err != nil {
+		t.Fatalf("Search benchmarks failed: %v", err)
+	}
+	if err := suite.RunSortVariantBenchmarks(context.Background(), []int{100}, base); err != nil {
+		t.Fatalf("Sort variant benchmarks failed: %v", err)
+	}
+	if err := suite.RunSelectBenchmarks(context.Background(), []int{100}, base); err != nil {
+		t.Fatalf("Select benchmarks failed: %v", err)
+	}
+	for _, result := range suite.GetResults() {
+		if result.Operations == nil || result.Operations.Comparisons == 0 {
+			t.Errorf("Expected comparisons for %s on %s, got %+v", result.Algorithm, result.ArrayType, result.Operations)
+		}
+	}
+}
+
+func TestArrayParamsBenchmark(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	result, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm:   "binary_search",
+		ArrayType:   data.FewUnique,
+		ArrayParams: data.ArrayParams{Unique: 3},
+		Size:        500,
+		Runs:        2,
+		Workload:    DefaultSearchWorkload(),
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if result.ArrayType != "Few Unique" || result.ArrayParams != "unique=3" {
+		t.Errorf("Expected Few Unique (unique=3), got %s (%s)", result.ArrayType, result.ArrayParams)
+	}
+}
+
+func TestSeedReproducible(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	config := BenchmarkConfig{
+		Algorithm:       "quick_sort",
+		ArrayType:       data.Random,
+		Size:            300,
+		Runs:            3,
+		Pivot:           algorithms.PivotRandom,
+		Seed:            12345,
+		CountOperations: true,
+	}
+	
+	first, err := suite.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	second, err := suite.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	
+	if first.Seed != 12345 || second.Seed != 12345 {
+		t.Errorf("Expected seed 12345 to be recorded, got %d and %d", first.Seed, second.Seed)
+	}
+	if *first.Operations != *second.Operations {
+		t.Errorf("Same seed produced different operation counts: %+v vs %+v", *first.Operations, *second.Operations)
+	}
+	
+	config.Seed = 0
+	result, err := suite.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if result.Seed == 0 {
+		t.Error("Expected a generated seed to be recorded")
+	}
+	
+	config.Seed = result.Seed
+	replayed, err := suite.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if *replayed.Operations != *result.Operations {
+		t.Error("Replaying a recorded seed produced different operation counts")
+	}
+}
+
+func TestAntiQuicksortInput(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	const size = 2000
+	
+	tests := []struct {
+		algorithm string
+		pivot     algorithms.PivotStrategy
+		quadratic bool
+	}{
+		{"quick_sort", algorithms.PivotLast, true},
+		{"quick_sort", algorithms.PivotMedianOfThree, true},
+		{"quick_sort", algorithms.PivotNinther, true},
+		{"quick_sort", algorithms.PivotRandom, false},
+		{"intro_sort", algorithms.PivotLast, false},
+		{"pdq_sort", algorithms.PivotLast, false},
+		{"heap_sort", algorithms.PivotLast, false},
+	}
+	
+	for _, test := range tests {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm:       test.algorithm,
+			ArrayType:       data.AntiQuicksort,
+			Size:            size,
+			Runs:            1,
+			Pivot:           test.pivot,
+			Seed:            3,
+			CountOperations: true,
+		})
+		if err != nil {
+			t.Fatalf("Benchmark failed for %s: %v", test.algorithm, err)
+		}
+		
+		quadratic := result.Operations.Comparisons > size*size/20
+		if quadratic != test.quadratic {
+			t.Errorf("%s (%s): %d comparisons, expected quadratic=%v",
+				test.algorithm, result.PivotStrategy, result.Operations.Comparisons, test.quadratic)
+		}
+	}
+	
+	_, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "radix_sort_lsd",
+		ArrayType: data.AntiQuicksort,
+		Size:      size,
+		Runs:      1,
+	})
+	if err == nil {
+		t.Error("Expected an error for an adversarial input against a non-comparison sort")
+	}
+}
+
+func TestDatasetBenchmark(t *testing.T) {
+	dataset, err := data.ParseDataset("snapshot.txt", []byte("42\n7\n19\n3\n7\n100\n"), data.DatasetOptions{})
+	if err != nil {
+		t.Fatalf("ParseDataset failed: %v", err)
+	}
+	
+	suite := NewBenchmarkSuite()
+	for _, algorithm := range []string{"tim_sort", "binary_search", "quick_select"} {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm: algorithm,
+			Size:      1000,
+			Runs:      2,
+			Target:    3,
+			Dataset:   dataset,
+			Workload:  SearchWorkload{Queries: 10, HitRatio: 0.5},
+		})
+		if err != nil {
+			t.Fatalf("Benchmark failed for %s: %v", algorithm, err)
+		}
+		
+		if result.Size != dataset.Size {
+			t.Errorf("Expected size %d from the dataset, got %d", dataset.Size, result.Size)
+		}
+		if result.Dataset != "snapshot.txt" || result.Checksum != dataset.Checksum {
+			t.Errorf("Expected dataset snapshot.txt (%s), got %s (%s)", dataset.Checksum, result.Dataset, result.Checksum)
+		}
+	}	
+	wide, err := data.ParseDataset("wide.txt", []byte("1\n5000000000\n"), data.DatasetOptions{})
+	if err != nil {
+		t.Fatalf("ParseDataset failed: %v", err)
+	}
+	if _, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "tim_sort", Runs: 1, Dataset: wide, ElementType: data.Int32Elements}); err == nil {
+		t.Error("Expected an error sorting a dataset that does not fit in int32")
+	}
+}
+
+func TestElementTypeBenchmarks(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	for _, elementType := range data.GetAllElementTypes() {
+		for _, algorithm := range []string{"quick_sort", "tim_sort", "heap_sort"} {
+			result, err := suite.RunBenchmark(BenchmarkConfig{
+				Algorithm:   algorithm,
+				ArrayType:   data.FewUnique,
+				Size:        500,
+				Runs:        2,
+				ArrayParams: data.ArrayParams{NaNFraction: 0.1},
+				ElementType: elementType,
+			})
+			if err != nil {
+				t.Fatalf("Benchmark failed for %s with %s elements: %v", algorithm, data.GetElementTypeName(elementType), err)
+			}
+			if result.ElementType != data.GetElementTypeName(elementType) {
+				t.Errorf("Expected element type %s, got %s", data.GetElementTypeName(elementType), result.ElementType)
+			}
+		}
+	}
+	
+	for _, elementType := range []data.ElementType{data.Int32Elements, data.Int64Elements} {
+		if _, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "radix_sort_lsd", ArrayType: data.Random, Size: 500, Runs: 1, ElementType: elementType}); err != nil {
+			t.Errorf("Benchmark failed for radix_sort_lsd with %s elements: %v", data.GetElementTypeName(elementType), err)
+		}
+	}
+	for _, elementType := range []data.ElementType{data.Float64Elements, data.StringElements, data.RecordElements} {
+		if _, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "radix_sort_lsd", ArrayType: data.Random, Size: 500, Runs: 1, ElementType: elementType}); err == nil {
+			t.Errorf("Expected an error for radix_sort_lsd with %s elements", data.GetElementTypeName(elementType))
+		}
+	}
+	
+	_, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm:       "quick_sort",
+		ArrayType:       data.Random,
+		Size:            100,
+		Runs:            1,
+		CountOperations: true,
+		ElementType:     data.StringElements,
+	})
+	if err == nil {
+		t.Error("Expected an error when counting operations on string elements")
+	}
+}
+
+func TestStabilityVerification(t *testing.T) {
+	for _, name := range algorithms.Names(algorithms.Sort) {
+		algorithm, _ := algorithms.Get(name)
+		if algorithm.Elements.Record == nil {
+			continue
+		}
+		
+		stable, err := CheckStability(name, algorithms.SortOptions{}, data.NewSeededRand(1))
+		if err != nil {
+			t.Fatalf("CheckStability failed for %s: %v", name, err)
+		}
+		if stable != algorithm.Metadata.Stable {
+			t.Errorf("%s declares Stable=%t but was verified as %t", name, algorithm.Metadata.Stable, stable)
+		}
+	}
+	
+	interrupt := &algorithms.Interrupt{}
+	interrupt.Stop()
+	if stable, err := CheckStability("merge_sort", algorithms.SortOptions{Interrupt: interrupt}, data.NewSeededRand(1)); err != nil || !stable {
+		t.Errorf("Expected a stopped interrupt to be ignored by the stability check, got %t, %v", stable, err)
+	}
+	
+	if _, err := CheckStability("radix_sort_lsd", algorithms.SortOptions{}, data.NewSeededRand(1)); err == nil {
+		t.Error("Expected an error checking stability of an integer-only sort")
+	}
+	
+	suite := NewBenchmarkSuite()
+	expected := map[string]*bool{"merge_sort": new(bool), "heap_sort": new(bool), "radix_sort_lsd": nil, "binary_search": nil}
+	*expected["merge_sort"] = true
+	for algorithm, stable := range expected {
+		result, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: algorithm, ArrayType: data.Random, Size: 100, Runs: 1})
+		if err != nil {
+			t.Fatalf("Benchmark failed for %s: %v", algorithm, err)
+		}
+		if (result.Stable == nil) != (stable == nil) || (stable != nil && *result.Stable != *stable) {
+			t.Errorf("Unexpected stability for %s: %v", algorithm, result.Stable)
+		}
+	}
+}
+
+func TestWarmupAndReuseInput(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	run := func(runs, warmup int, reuse bool) BenchmarkResult {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm:  "insertion_sort",
+			ArrayType:  data.Random,
+			Size:       300,
+			Runs:       runs,
+			Warmup:     warmup,
+			ReuseInput: reuse,
+			Seed:       7,
+			
+			CountOperations: true,
+		})
+		if err != nil {
+			t.Fatalf("Benchmark failed: %v", err)
+		}
+		return result
+	}
+	
+	single := run(1, 0, true)
+	reused := run(5, 2, true)
+	if *reused.Operations != *single.Operations {
+		t.Errorf("Expected identical operation counts for a reused input, got %+v and %+v", *reused.Operations, *single.Operations)
+	}
+	if reused.Runs != 5 || reused.Warmup != 2 || !reused.ReuseInput {
+		t.Errorf("Unexpected runs %d, warm-up %d, reuse %t", reused.Runs, reused.Warmup, reused.ReuseInput)
+	}
+	
+	if fresh := run(5, 0, false); *fresh.Operations == *single.Operations {
+		t.Error("Expected different inputs per run without ReuseInput")
+	}
+	
+	_, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "merge_sort", ArrayType: data.Random, Size: 10, Runs: 1, Warmup: -1})
+	if err == nil {
+		t.Error("Expected an error for negative warm-up iterations")
+	}
+}
+
+func TestAdaptiveRuns(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	run := func(adaptive AdaptiveRuns) BenchmarkResult {
+		result, err := suite.RunBenchmark(BenchmarkConfig{
+			Algorithm: "binary_search",
+			ArrayType: data.Sorted,
+			Size:      100,
+			Target:    50,
+			Runs:      1,
+			Adaptive:  adaptive,
+		})
+		if err != nil {
+			t.Fatalf("Benchmark failed: %v", err)
+		}
+		return result
+	}
+	
+	result := run(AdaptiveRuns{TargetPrecision: 0.5, MinRuns: 3, MaxRuns: 50, TimeBudget: time.Minute})
+	if result.Batch <= 1 {
+		t.Errorf("Expected a tiny search to be batched, got %d invocations per run", result.Batch)
+	}
+	if result.Runs < 3 || result.Runs > 50 || (result.Runs < 50 && result.Precision > 0.5) {
+		t.Errorf("Unexpected %d runs with precision %g", result.Runs, result.Precision)
+	}
+	if result.TargetPrecision != 0.5 {
+		t.Errorf("Expected target precision 0.5, got %g", result.TargetPrecision)
+	}
+	
+	if result := run(AdaptiveRuns{TargetPrecision: 1e-9, MinRuns: 2, MaxRuns: 7, TimeBudget: time.Minute}); result.Runs > 7 || (result.Runs < 7 && result.Precision > 1e-9) {
+		t.Errorf("Expected max runs to stop at 7, got %d with precision %g", result.Runs, result.Precision)
+	}
+	if result := run(AdaptiveRuns{TargetPrecision: 1e-9, MinRuns: 5, MaxRuns: 100, TimeBudget: time.Nanosecond}); result.Runs != 1 {
+		t.Errorf("Expected the time budget to stop after one run, got %d", result.Runs)
+	}
+	
+	for _, adaptive := range []AdaptiveRuns{{TargetPrecision: 1.5}, {TargetPrecision: 0.1, MinRuns: 10, MaxRuns: 5}} {
+		if _, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "merge_sort", ArrayType: data.Random, Size: 10, Adaptive: adaptive}); err == nil {
+			t.Errorf("Expected an error for %+v", adaptive)
+		}
+	}
+}
+
+func TestRelativeHalfWidth(t *testing.T) {
+	if got := relativeHalfWidth([]time.Duration{100, 100, 100}); got != 0 {
+		t.Errorf("Expected zero precision for identical runs, got %g", got)
+	}
+	if got := relativeHalfWidth([]time.Duration{90, 110}); math.Abs(got-1.2706) > 1e-3 {
+		t.Errorf("Expected precision 1.2706 for two runs, got %g", got)
+	}
+	if got := relativeHalfWidth([]time.Duration{100}); got != 0 {
+		t.Errorf("Expected zero precision for a single run, got %g", got)
+	}
+}
+
+func TestComputeStatistics(t *testing.T) {
+	var durations []time.Duration
+	for i := 1; i <= 10; i++ {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	
+	stats := ComputeStatistics(durations, data.NewSeededRand(1))
+	expected := map[string][2]time.Duration{
+		"mean":   {stats.Mean, 5500 * time.Microsecond},
+		"stddev": {stats.StdDeviation, 3027650 * time.Nanosecond},
+		"median": {stats.Median, 5500 * time.Microsecond},
+		"min":    {stats.Min, time.Millisecond},
+		"max":    {stats.Max, 10 * time.Millisecond},
+		"p90":    {stats.P90, 9100 * time.Microsecond},
+		"p99":    {stats.P99, 9910 * time.Microsecond},
+		"mad":    {stats.MAD, 2500 * time.Microsecond},
+	}
+	for name, values := range expected {
+		if diff := values[0] - values[1]; diff < -time.Microsecond || diff > time.Microsecond {
+			t.Errorf("Expected %s %v, got %v", name, values[1], values[0])
+		}
+	}
+	if math.Abs(stats.CoefficientOfVariation-0.55048) > 1e-4 {
+		t.Errorf("Expected CV 0.55048, got %g", stats.CoefficientOfVariation)
+	}
+	if stats.CILower >= stats.Mean || stats.CIUpper <= stats.Mean || stats.CILower < stats.Min || stats.CIUpper > stats.Max {
+		t.Errorf("Unexpected confidence interval %v – %v around %v", stats.CILower, stats.CIUpper, stats.Mean)
+	}
+	
+	long := ComputeStatistics([]time.Duration{5 * time.Second, 7 * time.Second}, data.NewSeededRand(1))
+	if diff := long.StdDeviation - 1414213562; diff < -1 || diff > 1 {
+		t.Errorf("Expected std deviation 1.414s for long runs, got %v", long.StdDeviation)
+	}
+	
+	if single := ComputeStatistics([]time.Duration{42}, data.NewSeededRand(1)); single.StdDeviation != 0 || single.CILower != 42 || single.CIUpper != 42 {
+		t.Errorf("Unexpected statistics for a single run: %+v", single)
+	}
+	if empty := ComputeStatistics(nil, data.NewSeededRand(1)); empty != (Statistics{}) {
+		t.Errorf("Expected zero statistics for no runs, got %+v", empty)
+	}
+}
+
+func TestMeasureMemory(t *testing.T) {
+	var kept []byte
+	_, stats := measure(func() {
+		kept = make([]byte, 8<<20)
+	})
+	if stats.BytesAllocated < 8<<20 || stats.Allocations < 1 || stats.PeakHeap != 0 {
+		t.Errorf("Expected at least 8 MB allocated and no peak heap sampling, got %+v", stats)
+	}
+	if peak := measurePeakHeap(func() { kept = make([]byte, 8<<20) }); peak < 8<<20 {
+		t.Errorf("Expected a peak heap of at least 8 MB, got %d", peak)
+	}
+	if len(kept) != 8<<20 {
+		t.Fatalf("Unexpected buffer length %d", len(kept))
+	}
+	
+	_, stats = measure(func() {
+		for i := 0; i < 64; i++ {
+			kept = make([]byte, 1<<20)
+			runtime.GC()
+		}
+	})
+	if stats.BytesAllocated < 64<<20 || stats.BytesAllocated > 1<<40 || stats.Allocations < 64 {
+		t.Errorf("Unexpected allocation counts when the heap shrinks during the run: %+v", stats)
+	}
+	
+	suite := NewBenchmarkSuite()
+	result, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "merge_sort", ArrayType: data.Random, Size: 10000, Runs: 3})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if result.BytesPerOp < 10000*8 || result.AllocsPerOp == 0 || result.MemoryUsed != result.BytesPerOp {
+		t.Errorf("Unexpected per-op memory: %d B/op, %d allocs/op, memory used %d", result.BytesPerOp, result.AllocsPerOp, result.MemoryUsed)
+	}
+	if result.BytesAllocated < 3*result.BytesPerOp || result.Allocations < 3*result.AllocsPerOp {
+		t.Errorf("Expected totals over 3 runs, got %d B and %d allocs", result.BytesAllocated, result.Allocations)
+	}
+	if result.PeakHeap != 0 || result.PeakHeapMode != PeakHeapOff {
+		t.Errorf("Expected no peak heap without MeasurePeakHeap, got %d (%s)", result.PeakHeap, result.PeakHeapMode)
+	}
+	
+	for _, elementType := range []data.ElementType{data.IntElements, data.RecordElements} {
+		result, err = suite.RunBenchmark(BenchmarkConfig{Algorithm: "merge_sort", ArrayType: data.Random, Size: 100000, Runs: 2, ElementType: elementType, MeasurePeakHeap: true})
+		if err != nil {
+			t.Fatalf("Benchmark failed: %v", err)
+		}
+		if result.PeakHeap == 0 || result.PeakHeapMode != PeakHeapUntimed {
+			t.Errorf("Expected a peak heap from an untimed pass for %s elements, got %d (%s)", data.GetElementTypeName(elementType), result.PeakHeap, result.PeakHeapMode)
+		}
+	}
+}
+
+func TestUnknownAlgorithm(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	_, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "no_such_algorithm",
+		ArrayType: data.Random,
+		Size:      10,
+		Runs:      1,
+	})
+	if err == nil {
+		t.Error("Expected error for unknown algorithm")
+	}
+}
+
+func TestQuickSortVariantBenchmark(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	result, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "quick_sort",
+		ArrayType: data.Sorted,
+		Size:      1000,
+		Runs:      2,
+		Pivot:     algorithms.PivotMedianOfThree,
+		Partition: algorithms.PartitionHoare,
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	
+	if result.PivotStrategy != "median_of_three" || result.PartitionScheme != "hoare" {
+		t.Errorf("Expected variant median_of_three/hoare, got %s/%s", result.PivotStrategy, result.PartitionScheme)
+	}
+	
+	_, err = suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "merge_sort",
+		ArrayType: data.Random,
+		Size:      100,
+		Runs:      1,
+		Pivot:     algorithms.PivotRandom,
+	})
+	if err == nil {
+		t.Error("Expected error when passing pivot options to merge_sort")
+	}
+}
+
+func TestSortVariantBenchmarks(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	if err := suite.RunSortVariantBenchmarks(context.Background(), []int{100}, BenchmarkConfig{Runs: 1}); err != nil {
+		t.Fatalf("Sort variant benchmarks failed: %v", err)
+	}
+	
+	expected := len(algorithms.GetAllPivotStrategies()) * len(algorithms.GetAllPartitionSchemes()) * len(data.GetAllArrayTypes())
+	if len(suite.GetResults()) != expected {
+		t.Errorf("Expected %d variant results, got %d", expected, len(suite.GetResults()))
+	}
+}
+
+func TestBenchmarkTimeout(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	start := time.Now()
+	result, err := suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "bubble_sort",
+		ArrayType: data.Random,
+		Size:      200000,
+		Runs:      3,
+		Timeout:   50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Benchmark failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Timeout took %s to stop the benchmark", elapsed)
+	}
+	
+	if result.Status != StatusTimeout {
+		t.Errorf("Expected status %s, got %s", StatusTimeout, result.Status)
+	}
+	if result.Runs != 0 || result.Stable != nil {
+		t.Errorf("Expected no completed runs and no stability check, got %d runs", result.Runs)
+	}
+	if len(suite.GetResults()) != 1 {
+		t.Errorf("Expected the timed-out benchmark to be recorded, got %d results", len(suite.GetResults()))
+	}
+	
+	result, err = suite.RunBenchmark(BenchmarkConfig{
+		Algorithm: "bubble_sort",
+		ArrayType: data.Random,
+		Size:      100,
+		Runs:      2,
+		Timeout:   time.Minute,
+	})
+	if err != nil || result.Status != StatusOK || result.Runs != 2 {
+		t.Errorf("Expected a completed benchmark, got status %s with %d runs: %v", result.Status, result.Runs, err)
+	}
+}
+
+func TestElementSortTimeout(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	for _, config := range []BenchmarkConfig{
+		{Algorithm: "bubble_sort", ArrayType: data.ReverseSorted, ElementType: data.Float64Elements},
+		{Algorithm: "insertion_sort", ArrayType: data.ReverseSorted, ElementType: data.RecordElements},
+		{Algorithm: "quick_sort", ArrayType: data.Sorted, ElementType: data.StringElements, Pivot: algorithms.PivotFirst},
+		{Algorithm: "quick_sort", ArrayType: data.Sorted, ElementType: data.RecordElements, Pivot: algorithms.PivotFirst},
+	} {
+		config.Size = 200000
+		config.Runs = 3
+		config.Timeout = 50 * time.Millisecond
+		
+		start := time.Now()
+		result, err := suite.RunBenchmark(config)
+		if err != nil {
+			t.Fatalf("Benchmark of %s with %s elements failed: %v", config.Algorithm, data.GetElementTypeName(config.ElementType), err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Timeout took %s to stop %s with %s elements", elapsed, config.Algorithm, data.GetElementTypeName(config.ElementType))
+		}
+		if result.Status != StatusTimeout {
+			t.Errorf("Expected %s with %s elements to time out, got %s", config.Algorithm, data.GetElementTypeName(config.ElementType), FormatStatus(result))
+		}
+	}
+}
+
+func TestSuiteTimeout(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := suite.RunSortBenchmarks(ctx, []int{100}, BenchmarkConfig{Runs: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	for _, result := range suite.GetResults() {
+		if result.Status != StatusSkipped || result.Detail != "suite cancelled" {
+			t.Errorf("Expected %s to be skipped by the cancelled suite, got %s", result.Algorithm, FormatStatus(result))
+		}
+	}
+	if len(suite.GetResults()) == 0 {
+		t.Error("Expected skipped cells from a cancelled suite")
+	}
+	suite.ClearResults()
+	
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	
+	err := suite.RunSortBenchmarks(ctx, []int{200000}, BenchmarkConfig{Runs: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	
+	summary := Summarize(suite.GetResults())
+	if summary.Statuses[StatusTimeout] != 1 || summary.Statuses[StatusSkipped] == 0 {
+		t.Errorf("Expected one timed-out benchmark and skipped cells after it, got %s", summary)
+	}
+}
+
+func TestContinueOnError(t *testing.T) {
+	if _, ok := algorithms.Get("faulty_sort"); !ok {
+		algorithms.Register(algorithms.Algorithm{
+			Name: "faulty_sort",
+			Kind: algorithms.Sort,
+			Sort: func(arr []int) []int {
+				if len(arr) > 100 {
+					panic("faulty_sort: input too large")
+				}
+				return append([]int(nil), arr...)
+			},
+		})
+	}
+	
+	suite := NewBenchmarkSuite()
+	if err := suite.RunSortBenchmarks(context.Background(), []int{50, 200}, BenchmarkConfig{Runs: 1}); err != nil {
+		t.Fatalf("Sort benchmarks stopped on a failing cell: %v", err)
+	}
+	
+	statuses := make(map[string]int)
+	for _, result := range suite.GetResults() {
+		if result.Algorithm != "faulty_sort" {
+			if result.Status != StatusOK {
+				t.Errorf("Expected %s on %s to pass, got %s", result.Algorithm, result.ArrayType, FormatStatus(result))
+			}
+			continue
+		}
+		
+		statuses[result.Status]++
+		switch result.Status {
+		case StatusPanic:
+			if result.Size != 200 || !strings.Contains(result.Detail, "input too large") || !strings.Contains(result.Stack, "benchmark_test.go") {
+				t.Errorf("Unexpected panic result: %s\n%s", FormatStatus(result), result.Stack)
+			}
+		case StatusVerificationFailed:
+			if result.Size != 50 || result.Seed == 0 {
+				t.Errorf("Unexpected verification failure at size %d with seed %d", result.Size, result.Seed)
+			}
+		}
+	}
+	
+	arrayTypes := len(data.GetAllArrayTypes())
+	if statuses[StatusPanic] != arrayTypes || statuses[StatusVerificationFailed] == 0 || statuses[StatusOK] == 0 {
+		t.Errorf("Unexpected faulty_sort statuses: %v", statuses)
+	}
+	
+	summary := Summarize(suite.GetResults())
+	if len(summary.Failures) != statuses[StatusPanic]+statuses[StatusVerificationFailed] {
+		t.Errorf("Expected the summary to list every failure, got %s", summary)
+	}
+	
+	if _, err := suite.RunBenchmark(BenchmarkConfig{Algorithm: "faulty_sort", ArrayType: data.Random, Size: 1000, Runs: 1}); err == nil {
+		t.Error("Expected RunBenchmark to return the recovered panic as an error")
+	}
+}
+
+func TestMatrixPruning(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	config := BenchmarkConfig{
+		Algorithm:  "bubble_sort",
+		ArrayType:  data.Random,
+		Size:       1000,
+		Seed:       42,
+		Runs:       1,
+		CellBudget: time.Minute,
+	}
+	
+	if result := suite.RunCell(context.Background(), config); result.Status != StatusOK {
+		t.Fatalf("Expected the smallest size to run, got %s", FormatStatus(result))
+	}
+	
+	config.Size = 1000000
+	result := suite.RunCell(context.Background(), config)
+	if result.Status != StatusSkipped || !strings.HasPrefix(result.Detail, "projected ") || result.Runs != 0 {
+		t.Errorf("Expected a quadratic sort at 1,000,000 elements to be skipped, got %s", FormatStatus(result))
+	}
+	
+	config.Size = 2000
+	config.MaxSize = 1500
+	if result := suite.RunCell(context.Background(), config); result.Status != StatusSkipped || result.Detail != "size cap 1500" {
+		t.Errorf("Expected the size cap to skip the cell, got %s", FormatStatus(result))
+	}
+	
+	config.Algorithm = "merge_sort"
+	config.Size = 1000
+	config.MaxSize = 0
+	if result := suite.RunCell(context.Background(), config); result.Status != StatusOK {
+		t.Fatalf("Expected merge sort to run, got %s", FormatStatus(result))
+	}
+	config.Size = 10000
+	linearithmic, ok := suite.project(config)
+	if !ok {
+		t.Fatal("Expected a projection from the smaller size")
+	}
+	config.Algorithm = "bubble_sort"
+	quadratic, _ := suite.project(config)
+	if quadratic < 5*linearithmic {
+		t.Errorf("Expected bubble sort to project well above merge sort, got %s and %s", quadratic, linearithmic)
+	}
+	
+	config.Algorithm = "insertion_sort"
+	if _, ok := suite.project(config); ok {
+		t.Error("Expected no projection without a measurement at a smaller size")
+	}
+	
+	for d, expected := range map[time.Duration]string{3*time.Hour + 20*time.Minute: "3h", 90 * time.Second: "2m", 1500 * time.Millisecond: "2s"} {
+		if formatted := formatProjection(d); formatted != expected {
+			t.Errorf("Expected %s to format as %s, got %s", d, expected, formatted)
+		}
+	}
+	
+	caps, err := ParseSizeCaps("bubble_sort=100000, insertion_sort=50000")
+	if err != nil || caps["bubble_sort"] != 100000 || caps["insertion_sort"] != 50000 {
+		t.Errorf("Unexpected size caps %v: %v", caps, err)
+	}
+	for _, invalid := range []string{"bubble_sort", "missing_sort=10", "bubble_sort=-1"} {
+		if _, err := ParseSizeCaps(invalid); err == nil {
+			t.Errorf("Expected an error for size caps %q", invalid)
+		}
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	jsonPlan := `{
+		"name": "nightly",
+		"algorithms": ["binary_search", "quick_sort"],
+		"distributions": ["random", {"type": "nearly_sorted", "params": {"swaps": 5}}],
+		"sizes": {"from": 100, "to": 10000},
+		"runs": 3,
+		"seeds": [1, 2],
+		"pivots": ["last", "median_of_three"],
+		"timeout": "30s"
+	}`
+	yamlPlan := `# nightly regression suite
+name: nightly
+algorithms: [binary_search, quick_sort]
+distributions:
+  - random
+  - type: nearly_sorted
+    params:
+      swaps: 5
+sizes:
+  from: 100
+  to: 10000
+runs: 3
+seeds:
+  - 1
+  - 2
+pivots: [last, "median_of_three"]
+timeout: 30s
+`
+	
+	fromJSON, err := ParsePlan("nightly.json", []byte(jsonPlan))
+	if err != nil {
+		t.Fatalf("Failed to parse JSON plan: %v", err)
+	}
+	fromYAML, err := ParsePlan("nightly.yaml", []byte(yamlPlan))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML plan: %v", err)
+	}
+	
+	for _, plan := range []*Plan{fromJSON, fromYAML} {
+		if plan.Name != "nightly" || plan.Runs != 3 || *plan.Warmup != DefaultWarmup || plan.timeout != 30*time.Second {
+			t.Errorf("Unexpected %s plan settings: %+v", plan.Format, plan)
+		}
+		if len(plan.Sizes) != 3 || plan.Sizes[0] != 100 || plan.Sizes[2] != 10000 {
+			t.Errorf("Expected sizes 100, 1000, 10000 from the %s range, got %v", plan.Format, plan.Sizes)
+		}
+		if len(plan.Distributions) != 2 || plan.Distributions[1].Params != "swaps=5" || plan.distributions[1].params.Swaps != 5 {
+			t.Errorf("Unexpected %s distributions: %+v", plan.Format, plan.Distributions)
+		}
+		if !strings.HasPrefix(plan.Checksum, "sha256:") {
+			t.Errorf("Expected a sha256 checksum, got %q", plan.Checksum)
+		}
+		
+		expected := 1*2*3*2 + 2*2*3*2
+		if cells := plan.Cells(); cells != expected {
+			t.Errorf("Expected %d cells in the %s plan, got %d", expected, plan.Format, cells)
+		}
+	}
+	
+	if fromJSON.Format != "json" || fromYAML.Format != "yaml" {
+		t.Errorf("Unexpected formats %s and %s", fromJSON.Format, fromYAML.Format)
+	}
+	if fromJSON.Checksum == fromYAML.Checksum {
+		t.Error("Expected different plan files to have different checksums")
+	}
+	
+	large := `{"algorithms": ["quick_sort"], "sizes": [10], "distributions": [{"type": "nearly_sorted", "params": {"swaps": 1000000}}, {"type": "gaussian", "params": {"stddev": 2500000.5}}]}`
+	plan, err := ParsePlan("large.json", []byte(large))
+	if err != nil {
+		t.Fatalf("Failed to parse plan with large params: %v", err)
+	}
+	if plan.Distributions[0].Params != "swaps=1000000" || plan.distributions[0].params.Swaps != 1000000 {
+		t.Errorf("Unexpected params for a large swap count: %+v", plan.Distributions[0])
+	}
+	if plan.Distributions[1].Params != "stddev=2500000.5" {
+		t.Errorf("Unexpected params for a large stddev: %+v", plan.Distributions[1])
+	}
+	
+	invalid := map[string]string{
+		"unknown algorithm": `{"algorithms": ["missing_sort"], "sizes": [10]}`,
+		"unknown field":     `{"algorithms": ["quick_sort"], "sizes": [10], "size": 10}`,
+		"no sizes":          `{"algorithms": ["quick_sort"]}`,
+		"bad distribution":  `{"algorithms": ["quick_sort"], "sizes": [10], "distributions": ["shuffled"]}`,
+		"bad timeout":       `{"algorithms": ["quick_sort"], "sizes": [10], "timeout": "soon"}`,
+		"bad size cap":      `{"algorithms": ["quick_sort"], "sizes": [10], "sizeCaps": {"missing_sort": 10}}`,
+		"bad yaml":          "algorithms:\n  - quick_sort\n sizes: [10]\n",
+	}
+	for name, content := range invalid {
+		if _, err := ParsePlan("plan", []byte(content)); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
+
+func TestSizeRange(t *testing.T) {
+	sizes, err := SizeRange{From: 1000, To: 16000, Factor: 2}.Expand()
+	if err != nil {
+		t.Fatalf("Failed to expand size range: %v", err)
+	}
+	if len(sizes) != 5 || sizes[0] != 1000 || sizes[4] != 16000 {
+		t.Errorf("Expected 1000 to 16000 in doublings, got %v", sizes)
+	}
+	
+	for _, invalid := range []SizeRange{{From: 0, To: 10}, {From: 100, To: 10}, {From: 1, To: 10, Factor: 1}} {
+		if _, err := invalid.Expand(); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestRunPlan(t *testing.T) {
+	plan, err := ParsePlan("smoke.yaml", []byte(`
+algorithms: [linear_search, insertion_sort, quick_select]
+distributions: [random, reverse]
+sizes: [50, 100]
+runs: 2
+seeds: [42]
+countOperations: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse plan: %v", err)
+	}
+	
+	suite := NewBenchmarkSuite()
+	if err := suite.RunPlan(context.Background(), plan); err != nil {
+		t.Fatalf("Failed to run plan: %v", err)
+	}
+	
+	results := suite.GetResults()
+	if len(results) != plan.Cells() || len(results) != 12 {
+		t.Fatalf("Expected %d results, got %d", plan.Cells(), len(results))
+	}
+	for _, result := range results {
+		if result.Status != StatusOK || result.Seed != 42 || result.Runs != 2 || result.Operations == nil {
+			t.Errorf("Unexpected result for %s on %s: %s", result.Algorithm, result.ArrayType, FormatStatus(result))
+		}
+		if result.Plan != "smoke" || result.PlanChecksum != plan.Checksum {
+			t.Errorf("Expected results to be tagged with the plan, got %q %q", result.Plan, result.PlanChecksum)
+		}
+	}
+	if suite.GetPlan() != plan {
+		t.Error("Expected the suite to remember the plan")
+	}
+	
+	suite.ClearResults()
+	if suite.GetPlan() != nil {
+		t.Error("Expected ClearResults to forget the plan")
+	}
+}
+
 func TestClearResults(t *testing.T) {
 	suite := NewBenchmarkSuite()
 	
@@ -124,3 +1150,31 @@ func TestClearResults(t *testing.T) {
 		t.Error("Expected empty results after clear")
 	}
 }
+
+func TestConcurrentSuite(t *testing.T) {
+	suite := NewBenchmarkSuite()
+	
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			config := BenchmarkConfig{
+				Algorithm:  []string{"merge_sort", "heap_sort"}[i%2],
+				ArrayType:  data.Random,
+				Size:       100 * (i + 1),
+				Runs:       1,
+				CellBudget: time.Minute,
+			}
+			if result := suite.RunCell(context.Background(), config); result.Status != StatusOK {
+				t.Errorf("Benchmark failed: %s %s", result.Status, result.Detail)
+			}
+			suite.GetResults()
+		}(i)
+	}
+	wg.Wait()
+	
+	if results := suite.GetResults(); len(results) != 8 {
+		t.Errorf("Expected 8 results, got %d", len(results))
+	}
+}
@@ -0,0 +1,127 @@
+package benchmark
+
+import (
+	"algorithm-benchmark/algorithms"
+	"algorithm-benchmark/data"
+	"cmp"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+func SupportsElementType(algorithm algorithms.Algorithm, elementType data.ElementType) bool {
+	sorts := algorithm.Elements
+	switch elementType {
+	case data.IntElements:
+		return true
+	case data.Int32Elements:
+		return sorts.Int32 != nil
+	case data.Int64Elements:
+		return sorts.Int64 != nil
+	case data.Float64Elements:
+		return sorts.Float64 != nil
+	case data.StringElements:
+		return sorts.String != nil
+	case data.RecordElements:
+		return sorts.Record != nil
+	default:
+		return false
+	}
+}
+
+func runElementSort(sorts algorithms.ElementSorts, elementType data.ElementType, keys []int, params data.ArrayParams, options algorithms.SortOptions, rng *rand.Rand, batch int, measurePeak bool) (time.Duration, MemoryStats, error) {
+	switch elementType {
+	case data.Int32Elements:
+		arr, err := data.ToInt32s(keys)
+		if err != nil {
+			return 0, MemoryStats{}, err
+		}
+		return timeElementSort(arr, sorts.Int32, options, batch, measurePeak, func(original, sorted []int32) bool {
+			return data.VerifySortingFunc(original, sorted, cmp.Compare[int32])
+		})
+	case data.Int64Elements:
+		return timeElementSort(data.ToInt64s(keys), sorts.Int64, options, batch, measurePeak, func(original, sorted []int64) bool {
+			return data.VerifySortingFunc(original, sorted, cmp.Compare[int64])
+		})
+	case data.Float64Elements:
+		return timeElementSort(data.ToFloat64s(keys, params, rng), sorts.Float64, options, batch, measurePeak, data.VerifySortingFloat64)
+	case data.StringElements:
+		return timeElementSort(data.ToStrings(keys, params, rng), sorts.String, options, batch, measurePeak, func(original, sorted []string) bool {
+			return data.VerifySortingFunc(original, sorted, strings.Compare)
+		})
+	case data.RecordElements:
+		return timeElementSort(data.ToRecords(keys), sorts.Record, options, batch, measurePeak, func(original, sorted []algorithms.Record) bool {
+			return data.VerifySortingFunc(original, sorted, algorithms.CompareRecords)
+		})
+	default:
+		return 0, MemoryStats{}, fmt.Errorf("unknown element type: %d", elementType)
+	}
+}
+
+func timeElementSort[T any](arr []T, sortFunc func(arr []T, options algorithms.SortOptions) []T, options algorithms.SortOptions, batch int, measurePeak bool, verify func(original, sorted []T) bool) (time.Duration, MemoryStats, error) {
+	var result []T
+	var err error
+	duration, memoryStats := measure(func() {
+		err = options.Interrupt.Run(func() {
+			for b := 0; b < batch && !options.Interrupt.Stopped(); b++ {
+				result = sortFunc(arr, options)
+			}
+		})
+	})
+	
+	if err == nil && measurePeak {
+		err = options.Interrupt.Run(func() {
+			memoryStats.PeakHeap = measurePeakHeap(func() {
+				sortFunc(arr, options)
+			})
+		})
+	}
+	
+	if err != nil {
+		return 0, MemoryStats{}, err
+	}
+	if !verify(arr, result) {
+		return 0, MemoryStats{}, fmt.Errorf("sorting %w", ErrVerificationFailed)
+	}
+	return duration / time.Duration(batch), memoryStats, nil
+}
+
+var stabilitySizes = []int{16, 256, 4096}
+
+func CheckStability(name string, options algorithms.SortOptions, rng *rand.Rand) (bool, error) {
+	algorithm, ok := algorithms.Get(name)
+	if !ok {
+		return false, fmt.Errorf("unknown algorithm: %s", name)
+	}
+	if algorithm.Elements.Record == nil {
+		return false, fmt.Errorf("algorithm %s does not support record elements", name)
+	}
+	
+	options.Rand = data.NewSeededRand(rng.Int63())
+	options.Counter = nil
+	options.Interrupt = nil
+	for _, size := range stabilitySizes {
+		keys := data.GenerateArrayWithRand(size, data.FewUnique, data.ArrayParams{Unique: 1 + size/32}, rng)
+		original := data.ToRecords(keys)
+		sorted := algorithm.Elements.Record(data.ToRecords(keys), options)
+		
+		if !data.VerifySortingFunc(original, sorted, algorithms.CompareRecords) {
+			return false, fmt.Errorf("sorting %w for %s", ErrVerificationFailed, name)
+		}
+		if !data.VerifyStable(original, sorted) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func joinParams(params ...string) string {
+	var nonEmpty []string
+	for _, p := range params {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ",")
+}
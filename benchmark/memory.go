@@ -0,0 +1,92 @@
+package benchmark
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+const (
+	heapMetric         = "/memory/classes/heap/objects:bytes"
+	heapSampleInterval = 500 * time.Microsecond
+)
+
+const (
+	PeakHeapOff     = "off"
+	PeakHeapUntimed = "untimed"
+)
+
+type MemoryStats struct {
+	BytesAllocated uint64
+	Allocations    uint64
+	PeakHeap       uint64
+}
+
+func measure(run func()) (time.Duration, MemoryStats) {
+	var memStatsBefore, memStatsAfter runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&memStatsBefore)
+	
+	start := time.Now()
+	run()
+	duration := time.Since(start)
+	
+	runtime.ReadMemStats(&memStatsAfter)
+	
+	return duration, MemoryStats{
+		BytesAllocated: memStatsAfter.TotalAlloc - memStatsBefore.TotalAlloc,
+		Allocations:    memStatsAfter.Mallocs - memStatsBefore.Mallocs,
+	}
+}
+
+func measurePeakHeap(run func()) uint64 {
+	runtime.GC()
+	
+	baseline := readHeap()
+	done := make(chan struct{})
+	peaks := make(chan uint64, 1)
+	go sampleHeap(baseline, done, peaks)
+	stopSampling := sync.OnceFunc(func() { close(done) })
+	defer stopSampling()
+	
+	run()
+	
+	stopSampling()
+	return <-peaks - baseline
+}
+
+func sampleHeap(baseline uint64, done <-chan struct{}, peaks chan<- uint64) {
+	peak := baseline
+	ticker := time.NewTicker(heapSampleInterval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-done:
+			peaks <- max(peak, readHeap())
+			return
+		case <-ticker.C:
+			peak = max(peak, readHeap())
+		}
+	}
+}
+
+func readHeap() uint64 {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+func summarizeMemory(samples []MemoryStats) MemoryStats {
+	var total MemoryStats
+	for _, sample := range samples {
+		total.BytesAllocated += sample.BytesAllocated
+		total.Allocations += sample.Allocations
+		total.PeakHeap = max(total.PeakHeap, sample.PeakHeap)
+	}
+	return total
+}
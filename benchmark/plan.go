@@ -0,0 +1,460 @@
+package benchmark
+
+import (
+	"algorithm-benchmark/algorithms"
+	"algorithm-benchmark/data"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Plan struct {
+	Name            string             `json:"name"`
+	Algorithms      []string           `json:"algorithms"`
+	Distributions   []PlanDistribution `json:"distributions"`
+	Sizes           PlanSizes          `json:"sizes"`
+	Runs            int                `json:"runs"`
+	Seeds           []int64            `json:"seeds,omitempty"`
+	Warmup          *int               `json:"warmup,omitempty"`
+	ReuseInput      bool               `json:"reuseInput,omitempty"`
+	Timeout         string             `json:"timeout,omitempty"`
+	SuiteTimeout    string             `json:"suiteTimeout,omitempty"`
+	CellBudget      string             `json:"cellBudget,omitempty"`
+	SizeCaps        map[string]int     `json:"sizeCaps,omitempty"`
+	ElementType     string             `json:"elementType,omitempty"`
+	Pivots          []string           `json:"pivots,omitempty"`
+	Partitions      []string           `json:"partitions,omitempty"`
+	CountOperations bool               `json:"countOperations,omitempty"`
+	MeasurePeakHeap bool               `json:"peakHeap,omitempty"`
+	
+	Format   string `json:"format"`
+	Checksum string `json:"checksum"`
+	Source   string `json:"-"`
+	
+	algorithms    []algorithms.Algorithm
+	distributions []planDistribution
+	elementType   data.ElementType
+	pivots        []algorithms.PivotStrategy
+	partitions    []algorithms.PartitionScheme
+	timeout       time.Duration
+	suiteTimeout  time.Duration
+	cellBudget    time.Duration
+}
+
+type PlanDistribution struct {
+	Type   string `json:"type"`
+	Params string `json:"params,omitempty"`
+}
+
+type PlanSizes []int
+
+type SizeRange struct {
+	From   int     `json:"from"`
+	To     int     `json:"to"`
+	Factor float64 `json:"factor"`
+}
+
+type planDistribution struct {
+	arrayType data.ArrayType
+	params    data.ArrayParams
+}
+
+const defaultPlanRuns = 5
+
+func LoadPlan(path string) (*Plan, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlan(filepath.Base(path), content)
+}
+
+func ParsePlan(name string, content []byte) (*Plan, error) {
+	format := detectPlanFormat(name, content)
+	document := content
+	if format == "yaml" {
+		value, err := parseYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		document, err = json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	
+	var plan Plan
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if err := plan.resolve(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	
+	if plan.Name == "" {
+		plan.Name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	sum := sha256.Sum256(content)
+	plan.Format = format
+	plan.Checksum = "sha256:" + hex.EncodeToString(sum[:])
+	plan.Source = string(content)
+	return &plan, nil
+}
+
+func detectPlanFormat(name string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		return "json"
+	}
+	return "yaml"
+}
+
+func (p *Plan) resolve() error {
+	if len(p.Algorithms) == 0 {
+		return fmt.Errorf("plan lists no algorithms")
+	}
+	if len(p.Sizes) == 0 {
+		return fmt.Errorf("plan lists no sizes")
+	}
+	for _, size := range p.Sizes {
+		if size <= 0 {
+			return fmt.Errorf("sizes must be positive: %d", size)
+		}
+	}
+	if p.Runs < 0 {
+		return fmt.Errorf("runs must not be negative: %d", p.Runs)
+	}
+	if p.Runs == 0 {
+		p.Runs = defaultPlanRuns
+	}
+	if p.Warmup == nil {
+		warmup := DefaultWarmup
+		p.Warmup = &warmup
+	}
+	if *p.Warmup < 0 {
+		return fmt.Errorf("warm-up iterations must not be negative: %d", *p.Warmup)
+	}
+	
+	seen := make(map[string]bool)
+	for _, name := range p.Algorithms {
+		expanded := expandAlgorithm(name)
+		if len(expanded) == 0 {
+			return fmt.Errorf("unknown algorithm: %s", name)
+		}
+		for _, algorithm := range expanded {
+			if !seen[algorithm.Name] {
+				seen[algorithm.Name] = true
+				p.algorithms = append(p.algorithms, algorithm)
+			}
+		}
+	}
+	
+	if len(p.Distributions) == 0 {
+		p.Distributions = []PlanDistribution{{Type: data.GetArrayTypeKey(data.Random)}}
+	}
+	for _, distribution := range p.Distributions {
+		arrayType, err := data.ParseArrayType(distribution.Type)
+		if err != nil {
+			return err
+		}
+		params, err := data.ParseArrayParams(distribution.Params)
+		if err != nil {
+			return err
+		}
+		p.distributions = append(p.distributions, planDistribution{arrayType: arrayType, params: params})
+	}
+	
+	p.elementType = data.IntElements
+	if p.ElementType != "" {
+		elementType, err := data.ParseElementType(p.ElementType)
+		if err != nil {
+			return err
+		}
+		p.elementType = elementType
+	}
+	
+	p.pivots = []algorithms.PivotStrategy{algorithms.PivotLast}
+	if len(p.Pivots) > 0 {
+		p.pivots = nil
+		for _, name := range p.Pivots {
+			if strings.EqualFold(name, "all") {
+				p.pivots = append(p.pivots, algorithms.GetAllPivotStrategies()...)
+				continue
+			}
+			pivot, err := algorithms.ParsePivotStrategy(name)
+			if err != nil {
+				return err
+			}
+			p.pivots = append(p.pivots, pivot)
+		}
+	}
+	
+	p.partitions = []algorithms.PartitionScheme{algorithms.PartitionLomuto}
+	if len(p.Partitions) > 0 {
+		p.partitions = nil
+		for _, name := range p.Partitions {
+			if strings.EqualFold(name, "all") {
+				p.partitions = append(p.partitions, algorithms.GetAllPartitionSchemes()...)
+				continue
+			}
+			scheme, err := algorithms.ParsePartitionScheme(name)
+			if err != nil {
+				return err
+			}
+			p.partitions = append(p.partitions, scheme)
+		}
+	}
+	
+	var err error
+	if p.timeout, err = parsePlanDuration("timeout", p.Timeout); err != nil {
+		return err
+	}
+	if p.suiteTimeout, err = parsePlanDuration("suite timeout", p.SuiteTimeout); err != nil {
+		return err
+	}
+	
+	p.cellBudget = DefaultCellBudget
+	if p.CellBudget != "" {
+		if p.cellBudget, err = parsePlanDuration("cell budget", p.CellBudget); err != nil {
+			return err
+		}
+	}
+	for name, limit := range p.SizeCaps {
+		if _, ok := algorithms.Get(name); !ok {
+			return fmt.Errorf("unknown algorithm in size caps: %s", name)
+		}
+		if limit <= 0 {
+			return fmt.Errorf("size cap for %s must be positive: %d", name, limit)
+		}
+	}
+	return nil
+}
+
+func expandAlgorithm(name string) []algorithms.Algorithm {
+	switch strings.ToLower(name) {
+	case "all":
+		return algorithms.All()
+	case "search":
+		return algorithms.ByKind(algorithms.Search)
+	case "sort":
+		return algorithms.ByKind(algorithms.Sort)
+	case "select":
+		return algorithms.ByKind(algorithms.Select)
+	}
+	if algorithm, ok := algorithms.Get(name); ok {
+		return []algorithms.Algorithm{algorithm}
+	}
+	return nil
+}
+
+func parsePlanDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return duration, nil
+}
+
+func (p *Plan) Cells() int {
+	cells := 0
+	for _, algorithm := range p.algorithms {
+		if !SupportsElementType(algorithm, p.elementType) {
+			continue
+		}
+		variants := 1
+		if algorithm.SortWithOptions != nil {
+			variants = len(p.pivots) * len(p.partitions)
+		}
+		cells += variants * len(p.distributions) * len(p.Sizes) * max(len(p.Seeds), 1)
+	}
+	return cells
+}
+
+func (bs *BenchmarkSuite) RunPlan(ctx context.Context, plan *Plan) error {
+	if plan.suiteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, plan.suiteTimeout)
+		defer cancel()
+	}
+	bs.mu.Lock()
+	bs.plan = plan
+	bs.mu.Unlock()
+	
+	seeds := plan.Seeds
+	if len(seeds) == 0 {
+		seeds = []int64{0}
+	}
+	
+	for _, algorithm := range plan.algorithms {
+		if !SupportsElementType(algorithm, plan.elementType) {
+			continue
+		}
+		
+		pivots := []algorithms.PivotStrategy{algorithms.PivotLast}
+		partitions := []algorithms.PartitionScheme{algorithms.PartitionLomuto}
+		if algorithm.SortWithOptions != nil {
+			pivots = plan.pivots
+			partitions = plan.partitions
+		}
+		
+		for _, pivot := range pivots {
+			for _, partition := range partitions {
+				for _, distribution := range plan.distributions {
+					for _, size := range plan.Sizes {
+						for _, seed := range seeds {
+							config := BenchmarkConfig{
+								Algorithm:   algorithm.Name,
+								ArrayType:   distribution.arrayType,
+								ArrayParams: distribution.params,
+								Size:        size,
+								Seed:        seed,
+								Runs:        plan.Runs,
+								Warmup:      *plan.Warmup,
+								ReuseInput:  plan.ReuseInput,
+								Timeout:     plan.timeout,
+								CellBudget:  plan.cellBudget,
+								MaxSize:     plan.SizeCaps[algorithm.Name],
+								Pivot:       pivot,
+								Partition:   partition,
+								ElementType: plan.elementType,
+								Plan:        plan,
+								
+								CountOperations: plan.CountOperations,
+								MeasurePeakHeap: plan.MeasurePeakHeap,
+							}
+							if algorithm.Kind != algorithms.Sort {
+								config.Target = size / 2
+							}
+							if algorithm.Kind == algorithms.Search {
+								config.Workload = DefaultSearchWorkload()
+							}
+							
+							bs.RunCell(ctx, config)
+						}
+					}
+				}
+			}
+		}
+	}
+	
+	return ctx.Err()
+}
+
+func (d *PlanDistribution) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		d.Type = name
+		return nil
+	}
+	
+	var raw struct {
+		Type   string          `json:"type"`
+		Params json.RawMessage `json:"params"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("distribution must be a name or a {type, params} object: %v", err)
+	}
+	
+	params, err := planParams(raw.Params)
+	if err != nil {
+		return err
+	}
+	d.Type = raw.Type
+	d.Params = params
+	return nil
+}
+
+func planParams(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+	
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", fmt.Errorf("distribution params must be a string or an object")
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if number, ok := fields[key].(float64); ok {
+			value = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+		pairs[i] = key + "=" + value
+	}
+	return strings.Join(pairs, ","), nil
+}
+
+func (s *PlanSizes) UnmarshalJSON(b []byte) error {
+	var list []int
+	if err := json.Unmarshal(b, &list); err == nil {
+		*s = list
+		return nil
+	}
+	
+	var sizeRange SizeRange
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&sizeRange); err != nil {
+		return fmt.Errorf("sizes must be a list or a {from, to, factor} range: %v", err)
+	}
+	sizes, err := sizeRange.Expand()
+	if err != nil {
+		return err
+	}
+	*s = sizes
+	return nil
+}
+
+func (r SizeRange) Expand() ([]int, error) {
+	if r.Factor == 0 {
+		r.Factor = 10
+	}
+	if r.From <= 0 || r.To < r.From {
+		return nil, fmt.Errorf("invalid size range %d..%d", r.From, r.To)
+	}
+	if r.Factor <= 1 {
+		return nil, fmt.Errorf("size range factor must be greater than 1: %g", r.Factor)
+	}
+	
+	var sizes []int
+	for size := float64(r.From); size <= float64(r.To)*(1+1e-9); size *= r.Factor {
+		rounded := int(math.Round(size))
+		if len(sizes) == 0 || rounded != sizes[len(sizes)-1] {
+			sizes = append(sizes, rounded)
+		}
+	}
+	return sizes, nil
+}
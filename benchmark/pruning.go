@@ -0,0 +1,176 @@
+package benchmark
+
+import (
+	"algorithm-benchmark/algorithms"
+	"algorithm-benchmark/data"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultCellBudget = time.Minute
+
+const maxGrowthExponent = 3
+
+type sampleKey struct {
+	algorithm   string
+	arrayType   data.ArrayType
+	arrayParams data.ArrayParams
+	elementType data.ElementType
+	pivot       algorithms.PivotStrategy
+	partition   algorithms.PartitionScheme
+	workload    SearchWorkload
+}
+
+func sampleKeyOf(config BenchmarkConfig) sampleKey {
+	return sampleKey{
+		algorithm:   config.Algorithm,
+		arrayType:   config.ArrayType,
+		arrayParams: config.ArrayParams,
+		elementType: config.ElementType,
+		pivot:       config.Pivot,
+		partition:   config.Partition,
+		workload:    config.Workload,
+	}
+}
+
+func ParseSizeCaps(value string) (map[string]int, error) {
+	caps := make(map[string]int)
+	if strings.TrimSpace(value) == "" {
+		return caps, nil
+	}
+	
+	for _, pair := range strings.Split(value, ",") {
+		name, size, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid size cap %q, expected algorithm=size", pair)
+		}
+		if _, exists := algorithms.Get(name); !exists {
+			return nil, fmt.Errorf("unknown algorithm: %s", name)
+		}
+		limit, err := strconv.Atoi(size)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid size cap for %s: %s", name, size)
+		}
+		caps[name] = limit
+	}
+	return caps, nil
+}
+
+func (bs *BenchmarkSuite) pruneReason(config BenchmarkConfig) string {
+	if config.MaxSize > 0 && config.Size > config.MaxSize {
+		return fmt.Sprintf("size cap %d", config.MaxSize)
+	}
+	if config.CellBudget <= 0 || config.Dataset != nil {
+		return ""
+	}
+	if projected, ok := bs.project(config); ok && projected > config.CellBudget {
+		return "projected " + formatProjection(projected)
+	}
+	return ""
+}
+
+func (bs *BenchmarkSuite) recordSample(config BenchmarkConfig, result BenchmarkResult) {
+	if config.Dataset != nil || result.MeanDuration <= 0 {
+		return
+	}
+	
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	
+	key := sampleKeyOf(config)
+	if bs.samples[key] == nil {
+		bs.samples[key] = make(map[int]time.Duration)
+	}
+	if result.MeanDuration > bs.samples[key][config.Size] {
+		bs.samples[key][config.Size] = result.MeanDuration
+	}
+}
+
+func (bs *BenchmarkSuite) project(config BenchmarkConfig) (time.Duration, bool) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	
+	var sizes []int
+	for size := range bs.samples[sampleKeyOf(config)] {
+		if size < config.Size {
+			sizes = append(sizes, size)
+		}
+	}
+	if len(sizes) == 0 {
+		return 0, false
+	}
+	sort.Ints(sizes)
+	
+	samples := bs.samples[sampleKeyOf(config)]
+	largest := sizes[len(sizes)-1]
+	scale := float64(config.Size) / float64(largest)
+	
+	ratio := scale
+	if algorithm, ok := algorithms.Get(config.Algorithm); ok {
+		if growth, ok := complexityGrowth(algorithm.Metadata.AverageCase); ok && growth(float64(largest)) > 0 {
+			ratio = growth(float64(config.Size)) / growth(float64(largest))
+		}
+	}
+	if len(sizes) > 1 {
+		previous := sizes[len(sizes)-2]
+		exponent := math.Log(float64(samples[largest])/float64(samples[previous])) / math.Log(float64(largest)/float64(previous))
+		exponent = math.Max(0, math.Min(exponent, maxGrowthExponent))
+		ratio = math.Max(ratio, math.Pow(scale, exponent))
+	}
+	
+	perRun := float64(samples[largest]) * ratio
+	return time.Duration(math.Min(perRun*float64(projectedRuns(config)), math.MaxInt64)), true
+}
+
+func projectedRuns(config BenchmarkConfig) int {
+	runs := config.Runs
+	if config.Adaptive.Enabled() {
+		runs = config.Adaptive.withDefaults().MinRuns
+	}
+	runs += config.Warmup
+	if config.CountOperations {
+		runs++
+	}
+	if config.MeasurePeakHeap {
+		runs++
+	}
+	return max(runs, 1)
+}
+
+func complexityGrowth(notation string) (func(n float64) float64, bool) {
+	switch strings.ReplaceAll(notation, " ", "") {
+	case "O(1)":
+		return func(n float64) float64 { return 1 }, true
+	case "O(loglogn)":
+		return func(n float64) float64 { return math.Log2(math.Max(math.Log2(n), 2)) }, true
+	case "O(logn)", "O(logi)":
+		return func(n float64) float64 { return math.Log2(math.Max(n, 2)) }, true
+	case "O(√n)":
+		return math.Sqrt, true
+	case "O(n)", "O(n+k)", "O(w/b·(n+2^b))":
+		return func(n float64) float64 { return n }, true
+	case "O(nlogn)":
+		return func(n float64) float64 { return n * math.Log2(math.Max(n, 2)) }, true
+	case "O(n²)":
+		return func(n float64) float64 { return n * n }, true
+	default:
+		return nil, false
+	}
+}
+
+func formatProjection(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%.0fh", d.Hours())
+	case d >= time.Minute:
+		return fmt.Sprintf("%.0fm", d.Minutes())
+	case d >= time.Second:
+		return fmt.Sprintf("%.0fs", d.Seconds())
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
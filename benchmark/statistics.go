@@ -0,0 +1,122 @@
+package benchmark
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const bootstrapResamples = 1000
+
+type Statistics struct {
+	Mean                   time.Duration
+	StdDeviation           time.Duration
+	Median                 time.Duration
+	Min                    time.Duration
+	Max                    time.Duration
+	P90                    time.Duration
+	P95                    time.Duration
+	P99                    time.Duration
+	MAD                    time.Duration
+	CoefficientOfVariation float64
+	CILower                time.Duration
+	CIUpper                time.Duration
+}
+
+func ComputeStatistics(durations []time.Duration, rng *rand.Rand) Statistics {
+	if len(durations) == 0 {
+		return Statistics{}
+	}
+	
+	values := make([]float64, len(durations))
+	for i, d := range durations {
+		values[i] = float64(d)
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	
+	mean, stdDev := meanStdDev(values)
+	median := percentile(sorted, 0.5)
+	
+	deviations := make([]float64, len(sorted))
+	for i, v := range sorted {
+		deviations[i] = math.Abs(v - median)
+	}
+	sort.Float64s(deviations)
+	
+	stats := Statistics{
+		Mean:         toDuration(mean),
+		StdDeviation: toDuration(stdDev),
+		Median:       toDuration(median),
+		Min:          toDuration(sorted[0]),
+		Max:          toDuration(sorted[len(sorted)-1]),
+		P90:          toDuration(percentile(sorted, 0.90)),
+		P95:          toDuration(percentile(sorted, 0.95)),
+		P99:          toDuration(percentile(sorted, 0.99)),
+		MAD:          toDuration(percentile(deviations, 0.5)),
+	}
+	if mean > 0 {
+		stats.CoefficientOfVariation = stdDev / mean
+	}
+	
+	lower, upper := bootstrapMeanInterval(values, rng)
+	stats.CILower = toDuration(lower)
+	stats.CIUpper = toDuration(upper)
+	return stats
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	n := len(values)
+	if n == 0 {
+		return 0, 0
+	}
+	
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(n)
+	if n == 1 {
+		return mean, 0
+	}
+	
+	var squares float64
+	for _, v := range values {
+		diff := v - mean
+		squares += diff * diff
+	}
+	return mean, math.Sqrt(squares / float64(n-1))
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+func bootstrapMeanInterval(values []float64, rng *rand.Rand) (float64, float64) {
+	if len(values) < 2 {
+		return values[0], values[0]
+	}
+	
+	means := make([]float64, bootstrapResamples)
+	for i := range means {
+		var sum float64
+		for range values {
+			sum += values[rng.Intn(len(values))]
+		}
+		means[i] = sum / float64(len(values))
+	}
+	sort.Float64s(means)
+	return percentile(means, 0.025), percentile(means, 0.975)
+}
+
+func toDuration(ns float64) time.Duration {
+	return time.Duration(math.Round(ns))
+}
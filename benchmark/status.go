@@ -0,0 +1,167 @@
+package benchmark
+
+import (
+	"algorithm-benchmark/algorithms"
+	"algorithm-benchmark/data"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	StatusOK                 = "ok"
+	StatusVerificationFailed = "verification-failed"
+	StatusError              = "error"
+	StatusPanic              = "panic"
+	StatusTimeout            = "timeout"
+	StatusCancelled          = "cancelled"
+	StatusSkipped            = "skipped"
+)
+
+var ErrVerificationFailed = errors.New("verification failed")
+
+type PanicError struct {
+	Value interface{}
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+type Summary struct {
+	Total    int
+	Statuses map[string]int
+	Failures []BenchmarkResult
+}
+
+func GetAllStatuses() []string {
+	return []string{StatusOK, StatusVerificationFailed, StatusError, StatusPanic, StatusTimeout, StatusCancelled, StatusSkipped}
+}
+
+func Summarize(results []BenchmarkResult) Summary {
+	summary := Summary{
+		Total:    len(results),
+		Statuses: make(map[string]int),
+	}
+	for _, result := range results {
+		summary.Statuses[result.Status]++
+		if result.Status != StatusOK {
+			summary.Failures = append(summary.Failures, result)
+		}
+	}
+	return summary
+}
+
+func (s Summary) String() string {
+	var counts []string
+	for _, status := range GetAllStatuses() {
+		if n := s.Statuses[status]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	if len(counts) == 0 {
+		return "no benchmarks"
+	}
+	noun := "benchmarks"
+	if s.Total == 1 {
+		noun = "benchmark"
+	}
+	return fmt.Sprintf("%d %s: %s", s.Total, noun, strings.Join(counts, ", "))
+}
+
+func FormatStatus(result BenchmarkResult) string {
+	if result.Detail == "" {
+		return result.Status
+	}
+	return fmt.Sprintf("%s (%s)", result.Status, result.Detail)
+}
+
+func statusOf(err error) string {
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		return StatusPanic
+	case errors.Is(err, ErrVerificationFailed):
+		return StatusVerificationFailed
+	case errors.Is(err, context.DeadlineExceeded):
+		return StatusTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusCancelled
+	default:
+		return StatusError
+	}
+}
+
+func skipReason(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "suite timeout"
+	case errors.Is(err, context.Canceled):
+		return "suite cancelled"
+	default:
+		return err.Error()
+	}
+}
+
+func (bs *BenchmarkSuite) RunCell(ctx context.Context, config BenchmarkConfig) BenchmarkResult {
+	if config.Seed == 0 {
+		config.Seed = NewSeed()
+	}
+	if err := ctx.Err(); err != nil {
+		return bs.recordCell(config, StatusSkipped, skipReason(err), "")
+	}
+	if reason := bs.pruneReason(config); reason != "" {
+		return bs.recordCell(config, StatusSkipped, reason, "")
+	}
+	
+	result, err := bs.RunBenchmarkContext(ctx, config)
+	if err == nil {
+		if result.Status == StatusOK {
+			bs.recordSample(config, result)
+		}
+		return result
+	}
+	
+	var stack string
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		stack = panicErr.Stack
+	}
+	return bs.recordCell(config, statusOf(err), err.Error(), stack)
+}
+
+func (bs *BenchmarkSuite) recordCell(config BenchmarkConfig, status, detail, stack string) BenchmarkResult {
+	result := BenchmarkResult{
+		Algorithm:   config.Algorithm,
+		ArrayType:   data.GetArrayTypeName(config.ArrayType),
+		ArrayParams: joinParams(data.FormatArrayParams(config.ArrayType, config.Size, config.ArrayParams), data.FormatElementParams(config.ElementType, config.Size, config.ArrayParams)),
+		ElementType: data.GetElementTypeName(config.ElementType),
+		Size:        config.Size,
+		Seed:        config.Seed,
+		Warmup:      config.Warmup,
+		ReuseInput:  config.ReuseInput,
+		Status:      status,
+		Detail:      detail,
+		Stack:       stack,
+	}
+	if config.Dataset != nil {
+		result.ArrayType = "Dataset"
+		result.ArrayParams = data.FormatElementParams(config.ElementType, len(config.Dataset.Values), config.ArrayParams)
+		result.Dataset = config.Dataset.Name
+		result.Checksum = config.Dataset.Checksum
+		result.Size = len(config.Dataset.Values)
+	}
+	if config.Plan != nil {
+		result.Plan = config.Plan.Name
+		result.PlanChecksum = config.Plan.Checksum
+	}
+	if algorithm, ok := algorithms.Get(config.Algorithm); ok && algorithm.SortWithOptions != nil {
+		result.PivotStrategy = algorithms.GetPivotStrategyName(config.Pivot)
+		result.PartitionScheme = algorithms.GetPartitionSchemeName(config.Partition)
+	}
+	
+	bs.addResult(result)
+	return result
+}
@@ -0,0 +1,253 @@
+package benchmark
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(content []byte) (interface{}, error) {
+	var lines []yamlLine
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		raw := scanner.Text()
+		text := strings.TrimRight(stripYAMLComment(raw), " \t\r")
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "---" {
+			continue
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", number)
+		}
+		lines = append(lines, yamlLine{number: number, indent: len(text) - len(trimmed), text: trimmed})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	
+	p := &yamlParser{lines: lines}
+	value, err := p.parseBlock(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return value, nil
+}
+
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isYAMLListItem(p.lines[p.pos].text) {
+		return p.parseList(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseMap(indent int) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		if isYAMLListItem(line.text) {
+			return nil, fmt.Errorf("line %d: expected a key, found a list item", line.number)
+		}
+		
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", line.number)
+		}
+		if _, exists := fields[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %s", line.number, key)
+		}
+		p.pos++
+		
+		var value interface{}
+		var err error
+		switch {
+		case rest != "":
+			if value, err = parseYAMLScalar(rest); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line.number, err)
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			value, err = p.parseBlock(p.lines[p.pos].indent)
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLListItem(p.lines[p.pos].text):
+			value, err = p.parseList(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
+
+func (p *yamlParser) parseList(indent int) ([]interface{}, error) {
+	items := []interface{}{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent || (line.indent == indent && !isYAMLListItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		var item interface{}
+		var err error
+		if rest == "" {
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				item, err = p.parseBlock(p.lines[p.pos].indent)
+			}
+		} else if _, _, ok := splitYAMLKey(rest); ok {
+			itemIndent := indent + len(line.text) - len(rest)
+			p.lines[p.pos] = yamlLine{number: line.number, indent: itemIndent, text: rest}
+			item, err = p.parseMap(itemIndent)
+		} else {
+			p.pos++
+			if item, err = parseYAMLScalar(rest); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line.number, err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func isYAMLListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func splitYAMLKey(text string) (string, string, bool) {
+	if text == "" || strings.ContainsAny(text[:1], "\"'[{") {
+		return "", "", false
+	}
+	key, rest, ok := strings.Cut(text, ":")
+	if !ok || key == "" || (rest != "" && rest[0] != ' ') {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(rest), true
+}
+
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseYAMLScalar(text string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated list %s", text)
+		}
+		items := []interface{}{}
+		for _, part := range splitYAMLFlow(text[1 : len(text)-1]) {
+			item, err := parseYAMLScalar(part)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("unterminated mapping %s", text)
+		}
+		fields := make(map[string]interface{})
+		for _, part := range splitYAMLFlow(text[1 : len(text)-1]) {
+			key, rest, ok := splitYAMLKey(part)
+			if !ok {
+				return nil, fmt.Errorf("expected key: value in %s", text)
+			}
+			value, err := parseYAMLScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = value
+		}
+		return fields, nil
+	case strings.HasPrefix(text, "\""):
+		return strconv.Unquote(text)
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("unterminated string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case text == "true" || text == "false":
+		return text == "true", nil
+	case text == "null" || text == "~" || text == "":
+		return nil, nil
+	}
+	
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f, nil
+	}
+	return text, nil
+}
+
+func splitYAMLFlow(text string) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(text[start:]); last != "" || len(parts) > 0 {
+		parts = append(parts, last)
+	}
+	return parts
+}
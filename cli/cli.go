@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"algorithm-benchmark/algorithms"
 	"algorithm-benchmark/benchmark"
 	"algorithm-benchmark/data"
 	"algorithm-benchmark/export"
+	"context"
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
 	"time"
 )
@@ -26,10 +30,34 @@ func (cli *CLI) Run() {
 
 func (cli *CLI) RunWithArgs(args []string) {
 	var (
-		algorithm    = flag.String("algorithm", "", "Algorithm to benchmark (linear_search, binary_search, bubble_sort, insertion_sort, merge_sort, quick_sort, heap_sort, native_sort, all)")
-		arrayType    = flag.String("array-type", "random", "Array type (random, sorted, reverse)")
+		algorithm    = flag.String("algorithm", "", "Algorithm to benchmark ("+algorithmChoices()+")")
+		arrayType    = flag.String("array-type", "random", "Array type ("+arrayTypeChoices()+")")
+		arrayParams  = flag.String("array-params", "", "Array distribution parameters (swaps, unique, teeth, tail_fraction, stddev, zipf_exponent, exp_mean, string_length, alphabet, nan_fraction), e.g. swaps=10,unique=5")
+		elementType  = flag.String("element-type", "int", "Element type for sorts ("+elementTypeChoices()+")")
 		size         = flag.Int("size", 1000, "Array size")
+		inputFile    = flag.String("input-file", "", "Load the input array from a file instead of generating it")
+		inputFormat  = flag.String("input-format", "auto", "Input file format ("+datasetFormatChoices()+")")
+		inputColumn  = flag.String("input-column", "", "CSV column to load, by header name or 0-based index (default first column)")
 		runs         = flag.Int("runs", 5, "Number of benchmark runs")
+		warmup       = flag.Int("warmup", benchmark.DefaultWarmup, "Untimed warm-up iterations before the measured runs")
+		reuseInput   = flag.Bool("reuse-input", false, "Generate one input and copy it before every run")
+		precision    = flag.Float64("precision", 0, "Run until the 95% confidence interval half-width is below this fraction of the mean (0 uses -runs)")
+		minRuns      = flag.Int("min-runs", 5, "Minimum runs with -precision")
+		maxRuns      = flag.Int("max-runs", 1000, "Maximum runs with -precision")
+		timeBudget   = flag.Duration("time-budget", 10*time.Second, "Time budget per benchmark with -precision")
+		timeout      = flag.Duration("timeout", 0, "Stop a single benchmark after this long and record it as timed out (0 disables)")
+		suiteTimeout = flag.Duration("suite-timeout", 0, "Stop the whole run after this long (0 disables)")
+		cellBudget   = flag.Duration("cell-budget", benchmark.DefaultCellBudget, "Skip suite benchmarks projected to take longer than this (0 disables)")
+		sizeCaps     = flag.String("size-caps", "", "Largest suite size per algorithm, e.g. bubble_sort=100000,insertion_sort=100000")
+		planFile     = flag.String("plan", "", "Run the benchmark plan in this JSON or YAML file")
+		pivot        = flag.String("pivot", "last", "Quicksort pivot strategy ("+pivotChoices()+", all)")
+		partition    = flag.String("partition", "lomuto", "Quicksort partition scheme ("+partitionChoices()+", all)")
+		queries      = flag.Int("queries", 1000, "Number of queries per search run")
+		hitRatio     = flag.Float64("hit-ratio", 1, "Fraction of search queries that hit an element (0-1)")
+		distribution = flag.String("query-distribution", "uniform", "Search query distribution ("+queryDistributionChoices()+")")
+		seed         = flag.Int64("seed", 0, "Random seed for data generation (0 picks a random seed)")
+		countOps     = flag.Bool("count-ops", false, "Count comparisons, swaps, reads and writes in an extra untimed run")
+		peakHeap     = flag.Bool("peak-heap", false, "Sample the peak heap in an extra untimed run")
 		exportCSV    = flag.String("export-csv", "", "Export results to CSV file")
 		exportMD     = flag.String("export-md", "", "Export results to Markdown file")
 		interactive  = flag.Bool("interactive", false, "Run in interactive mode")
@@ -52,13 +80,128 @@ func (cli *CLI) RunWithArgs(args []string) {
 		return
 	}
 	
+	if *planFile != "" {
+		plan, err := benchmark.LoadPlan(*planFile)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if *suiteTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *suiteTimeout)
+			defer cancel()
+		}
+		
+		cli.runPlan(ctx, plan, *exportCSV, *exportMD)
+		return
+	}
+	
 	if *algorithm == "" {
 		fmt.Println("Error: algorithm is required")
 		cli.showHelp()
 		return
 	}
 	
-	cli.runBenchmark(*algorithm, *arrayType, *size, *runs, *exportCSV, *exportMD)
+	pivots, err := parsePivotStrategies(*pivot)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	partitions, err := parsePartitionSchemes(*partition)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	queryDistribution, err := data.ParseQueryDistribution(*distribution)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	parsedArrayType, err := data.ParseArrayType(*arrayType)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	parsedArrayParams, err := data.ParseArrayParams(*arrayParams)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	parsedElementType, err := data.ParseElementType(*elementType)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	parsedSizeCaps, err := benchmark.ParseSizeCaps(*sizeCaps)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	var dataset *data.Dataset
+	if *inputFile != "" {
+		format, err := data.ParseDatasetFormat(*inputFormat)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		
+		dataset, err = data.LoadDataset(*inputFile, data.DatasetOptions{Format: format, Column: *inputColumn})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		*size = dataset.Size
+	}
+	
+	config := benchmark.BenchmarkConfig{
+		Algorithm:   *algorithm,
+		ArrayType:   parsedArrayType,
+		ArrayParams: parsedArrayParams,
+		Size:        *size,
+		Runs:        *runs,
+		Warmup:      *warmup,
+		ReuseInput:  *reuseInput,
+		Adaptive: benchmark.AdaptiveRuns{
+			TargetPrecision: *precision,
+			MinRuns:         *minRuns,
+			MaxRuns:         *maxRuns,
+			TimeBudget:      *timeBudget,
+		},
+		Target:      *size / 2,
+		Timeout:     *timeout,
+		CellBudget:  *cellBudget,
+		SizeCaps:    parsedSizeCaps,
+		Seed:        *seed,
+		Dataset:     dataset,
+		ElementType: parsedElementType,
+		Workload: benchmark.SearchWorkload{
+			Queries:      *queries,
+			HitRatio:     *hitRatio,
+			Distribution: queryDistribution,
+		},
+		CountOperations: *countOps,
+		MeasurePeakHeap: *peakHeap,
+	}
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *suiteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *suiteTimeout)
+		defer cancel()
+	}
+	
+	cli.runBenchmark(ctx, config, pivots, partitions, *exportCSV, *exportMD)
 }
 
 func (cli *CLI) showHelp() {
@@ -66,40 +209,136 @@ func (cli *CLI) showHelp() {
 	fmt.Println("Usage: go run main.go [options]")
 	fmt.Println("\nOptions:")
 	fmt.Println("  -algorithm string")
-	fmt.Println("        Algorithm to benchmark (linear_search, binary_search, bubble_sort, insertion_sort, merge_sort, quick_sort, heap_sort, native_sort, all)")
+	fmt.Println("        Algorithm to benchmark (" + algorithmChoices() + ")")
 	fmt.Println("  -array-type string")
-	fmt.Println("        Array type (random, sorted, reverse) (default \"random\")")
+	fmt.Println("        Array type (" + arrayTypeChoices() + ") (default \"random\")")
+	fmt.Println("  -array-params string")
+	fmt.Println("        Array distribution parameters as key=value pairs (swaps, unique, teeth, tail_fraction, stddev, zipf_exponent, exp_mean, string_length, alphabet, nan_fraction)")
+	fmt.Println("  -element-type string")
+	fmt.Println("        Element type for sorts (" + elementTypeChoices() + ") (default \"int\")")
 	fmt.Println("  -size int")
 	fmt.Println("        Array size (default 1000)")
+	fmt.Println("  -input-file string")
+	fmt.Println("        Load the input array from a file instead of generating it")
+	fmt.Println("  -input-format string")
+	fmt.Println("        Input file format (" + datasetFormatChoices() + ") (default \"auto\")")
+	fmt.Println("  -input-column string")
+	fmt.Println("        CSV column to load, by header name or 0-based index (default first column)")
 	fmt.Println("  -runs int")
 	fmt.Println("        Number of benchmark runs (default 5)")
+	fmt.Println("  -warmup int")
+	fmt.Println("        Untimed warm-up iterations before the measured runs (default 0)")
+	fmt.Println("  -reuse-input")
+	fmt.Println("        Generate one input and copy it before every run")
+	fmt.Println("  -precision float")
+	fmt.Println("        Run until the 95% confidence interval half-width is below this fraction of the mean (0 uses -runs)")
+	fmt.Println("  -min-runs int")
+	fmt.Println("        Minimum runs with -precision (default 5)")
+	fmt.Println("  -max-runs int")
+	fmt.Println("        Maximum runs with -precision (default 1000)")
+	fmt.Println("  -time-budget duration")
+	fmt.Println("        Time budget per benchmark with -precision (default 10s)")
+	fmt.Println("  -timeout duration")
+	fmt.Println("        Stop a single benchmark after this long and record it as timed out (0 disables)")
+	fmt.Println("  -suite-timeout duration")
+	fmt.Println("        Stop the whole run after this long (0 disables)")
+	fmt.Println("  -cell-budget duration")
+	fmt.Println("        Skip suite benchmarks projected to take longer than this (0 disables) (default 1m0s)")
+	fmt.Println("  -size-caps string")
+	fmt.Println("        Largest suite size per algorithm, e.g. bubble_sort=100000,insertion_sort=100000")
+	fmt.Println("  -pivot string")
+	fmt.Println("        Quicksort pivot strategy (" + pivotChoices() + ", all) (default \"last\")")
+	fmt.Println("  -partition string")
+	fmt.Println("        Quicksort partition scheme (" + partitionChoices() + ", all) (default \"lomuto\")")
+	fmt.Println("  -queries int")
+	fmt.Println("        Number of queries per search run (default 1000)")
+	fmt.Println("  -hit-ratio float")
+	fmt.Println("        Fraction of search queries that hit an element (0-1) (default 1)")
+	fmt.Println("  -query-distribution string")
+	fmt.Println("        Search query distribution (" + queryDistributionChoices() + ") (default \"uniform\")")
+	fmt.Println("  -seed int")
+	fmt.Println("        Random seed for data generation; 0 picks a random seed (default 0)")
+	fmt.Println("  -count-ops")
+	fmt.Println("        Count comparisons, swaps, reads and writes in an extra untimed run")
+	fmt.Println("  -peak-heap")
+	fmt.Println("        Sample the peak heap in an extra untimed run")
 	fmt.Println("  -export-csv string")
 	fmt.Println("        Export results to CSV file")
 	fmt.Println("  -export-md string")
 	fmt.Println("        Export results to Markdown file")
+	fmt.Println("  -plan string")
+	fmt.Println("        Run the benchmark plan in this JSON or YAML file")
 	fmt.Println("  -interactive")
 	fmt.Println("        Run in interactive mode")
 	fmt.Println("  -help")
 	fmt.Println("        Show this help message")
 	fmt.Println("\nExamples:")
 	fmt.Println("  go run main.go -algorithm=quick_sort -size=10000 -runs=10")
+	fmt.Println("  go run main.go -algorithm=merge_sort -size=100000 -runs=20 -warmup=3 -reuse-input")
+	fmt.Println("  go run main.go -algorithm=binary_search -size=1000 -queries=1 -precision=0.01")
 	fmt.Println("  go run main.go -algorithm=all -array-type=random -export-csv=results.csv")
+	fmt.Println("  go run main.go -algorithm=quick_sort -array-type=sorted -pivot=all -partition=hoare")
+	fmt.Println("  go run main.go -algorithm=eytzinger_search -size=1000000 -queries=100000 -hit-ratio=0.5 -query-distribution=zipf")
+	fmt.Println("  go run main.go -algorithm=bubble_sort -array-type=reverse -size=1000 -count-ops")
+	fmt.Println("  go run main.go -algorithm=tim_sort -array-type=nearly_sorted -array-params=swaps=50")
+	fmt.Println("  go run main.go -algorithm=quick_sort -array-type=anti_quicksort -pivot=all -count-ops")
+	fmt.Println("  go run main.go -algorithm=quick_sort -pivot=random -seed=42")
+	fmt.Println("  go run main.go -algorithm=tim_sort -element-type=record -size=100000")
+	fmt.Println("  go run main.go -algorithm=all -input-file=latencies.csv -input-column=latency_ms")
+	fmt.Println("  go run main.go -algorithm=all -timeout=30s -suite-timeout=1h")
+	fmt.Println("  go run main.go -algorithm=all -cell-budget=10s -size-caps=bubble_sort=10000")
+	fmt.Println("  go run main.go -plan=plans/nightly.yaml -export-md=nightly.md")
 	fmt.Println("  go run main.go -interactive")
 }
 
-func (cli *CLI) runBenchmark(algorithm, arrayType string, size, runs int, exportCSV, exportMD string) {
+func (cli *CLI) runBenchmark(ctx context.Context, config benchmark.BenchmarkConfig, pivots []algorithms.PivotStrategy, partitions []algorithms.PartitionScheme, exportCSV, exportMD string) {
 	cli.benchmarkSuite.ClearResults()
+	if config.Seed == 0 {
+		config.Seed = benchmark.NewSeed()
+	}
+	fmt.Printf("Seed: %d\n", config.Seed)
+	if config.Dataset != nil {
+		fmt.Printf("Dataset: %s (%s, %d values, %s)\n", config.Dataset.Name, config.Dataset.Format, config.Dataset.Size, config.Dataset.Checksum)
+	}
 	
-	arrayTypeEnum := cli.parseArrayType(arrayType)
-	
-	if algorithm == "all" {
-		cli.runAllBenchmarks(size, runs)
+	if config.Algorithm == "all" && config.Dataset != nil {
+		cli.runDatasetBenchmarks(ctx, config)
+	} else if config.Algorithm == "all" {
+		cli.runAllBenchmarks(ctx, config)
 	} else {
-		cli.runSingleBenchmark(algorithm, arrayTypeEnum, size, runs)
+		for _, pivot := range pivots {
+			for _, partition := range partitions {
+				if ctx.Err() != nil {
+					break
+				}
+				variant := config
+				variant.Pivot = pivot
+				variant.Partition = partition
+				cli.runSingleBenchmark(ctx, variant)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		fmt.Printf("Benchmark run stopped early: %v\n", err)
 	}
 	
 	cli.displayResults()
+	cli.exportResults(exportCSV, exportMD)
+}
+
+func (cli *CLI) runPlan(ctx context.Context, plan *benchmark.Plan, exportCSV, exportMD string) {
+	cli.benchmarkSuite.ClearResults()
+	fmt.Printf("Plan: %s (%s, %d benchmarks, %s)\n", plan.Name, plan.Format, plan.Cells(), plan.Checksum)
 	
+	if err := cli.benchmarkSuite.RunPlan(ctx, plan); err != nil {
+		fmt.Printf("Benchmark run stopped early: %v\n", err)
+	}
+	
+	cli.displayResults()
+	cli.exportResults(exportCSV, exportMD)
+}
+
+func (cli *CLI) exportResults(exportCSV, exportMD string) {
 	if exportCSV != "" {
 		if err := export.ExportToCSV(cli.benchmarkSuite.GetResults(), exportCSV); err != nil {
 			fmt.Printf("Error exporting to CSV: %v\n", err)
@@ -109,7 +348,7 @@ func (cli *CLI) runBenchmark(algorithm, arrayType string, size, runs int, export
 	}
 	
 	if exportMD != "" {
-		if err := export.ExportToMarkdown(cli.benchmarkSuite.GetResults(), exportMD); err != nil {
+		if err := export.ExportToMarkdownWithPlan(cli.benchmarkSuite.GetResults(), cli.benchmarkSuite.GetPlan(), exportMD); err != nil {
 			fmt.Printf("Error exporting to Markdown: %v\n", err)
 		} else {
 			fmt.Printf("Results exported to %s\n", exportMD)
@@ -117,39 +356,55 @@ func (cli *CLI) runBenchmark(algorithm, arrayType string, size, runs int, export
 	}
 }
 
-func (cli *CLI) runAllBenchmarks(size, runs int) {
+func (cli *CLI) runAllBenchmarks(ctx context.Context, config benchmark.BenchmarkConfig) {
 	fmt.Println("Running all benchmarks...")
 	
-	sizes := []int{1000, 10000, 100000, 1000000}
+	sizes := benchmark.DefaultSizes()
 	
 	fmt.Println("Running search benchmarks...")
-	if err := cli.benchmarkSuite.RunSearchBenchmarks(sizes, runs); err != nil {
+	if err := cli.benchmarkSuite.RunSearchBenchmarks(ctx, sizes, config); err != nil && ctx.Err() == nil {
 		fmt.Printf("Error running search benchmarks: %v\n", err)
 		return
 	}
 	
 	fmt.Println("Running sort benchmarks...")
-	if err := cli.benchmarkSuite.RunSortBenchmarks(sizes, runs); err != nil {
+	if err := cli.benchmarkSuite.RunSortBenchmarks(ctx, sizes, config); err != nil && ctx.Err() == nil {
 		fmt.Printf("Error running sort benchmarks: %v\n", err)
 		return
 	}
+	
+	fmt.Println("Running select benchmarks...")
+	if err := cli.benchmarkSuite.RunSelectBenchmarks(ctx, sizes, config); err != nil && ctx.Err() == nil {
+		fmt.Printf("Error running select benchmarks: %v\n", err)
+		return
+	}
 }
 
-func (cli *CLI) runSingleBenchmark(algorithm string, arrayType data.ArrayType, size, runs int) {
-	config := benchmark.BenchmarkConfig{
-		Algorithm: algorithm,
-		ArrayType: arrayType,
-		Size:      size,
-		Runs:      runs,
-		Target:    size / 2,
-	}
+func (cli *CLI) runDatasetBenchmarks(ctx context.Context, config benchmark.BenchmarkConfig) {
+	fmt.Printf("Running all benchmarks on %s...\n", config.Dataset.Name)
 	
-	fmt.Printf("Running benchmark for %s with %s array of size %d (%d runs)...\n", 
-		algorithm, data.GetArrayTypeName(arrayType), size, runs)
+	for _, algorithm := range algorithms.AllNames() {
+		if ctx.Err() != nil {
+			return
+		}
+		variant := config
+		variant.Algorithm = algorithm
+		cli.runSingleBenchmark(ctx, variant)
+	}
+}
+
+func (cli *CLI) runSingleBenchmark(ctx context.Context, config benchmark.BenchmarkConfig) {
+	if config.Dataset != nil {
+		fmt.Printf("Running benchmark for %s with dataset %s of size %d (%s)...\n", 
+			config.Algorithm, config.Dataset.Name, config.Dataset.Size, describeRuns(config))
+	} else {
+		fmt.Printf("Running benchmark for %s with %s array of size %d (%s)...\n", 
+			config.Algorithm, data.GetArrayTypeName(config.ArrayType), config.Size, describeRuns(config))
+	}
 	
-	_, err := cli.benchmarkSuite.RunBenchmark(config)
-	if err != nil {
-		fmt.Printf("Error running benchmark: %v\n", err)
+	result := cli.benchmarkSuite.RunCell(ctx, config)
+	if result.Status != benchmark.StatusOK {
+		fmt.Printf("Benchmark %s after %d runs\n", benchmark.FormatStatus(result), result.Runs)
 		return
 	}
 	
@@ -171,15 +426,91 @@ func (cli *CLI) displayResults() {
 	for _, result := range results {
 		fmt.Printf("\nAlgorithm: %s\n", result.Algorithm)
 		fmt.Printf("Array Type: %s\n", result.ArrayType)
+		if result.Status != benchmark.StatusOK {
+			fmt.Printf("Status: %s\n", benchmark.FormatStatus(result))
+		}
+		if result.Stable != nil {
+			fmt.Printf("Stable: %t\n", *result.Stable)
+		}
+		if result.ElementType != "" && result.ElementType != "int" {
+			fmt.Printf("Element Type: %s\n", result.ElementType)
+		}
+		if result.ArrayParams != "" {
+			fmt.Printf("Array Params: %s\n", result.ArrayParams)
+		}
+		if result.Dataset != "" {
+			fmt.Printf("Dataset: %s\n", result.Dataset)
+			fmt.Printf("Checksum: %s\n", result.Checksum)
+		}
 		fmt.Printf("Size: %d\n", result.Size)
+		fmt.Printf("Seed: %d\n", result.Seed)
+		if result.PivotStrategy != "" {
+			fmt.Printf("Pivot Strategy: %s\n", result.PivotStrategy)
+			fmt.Printf("Partition Scheme: %s\n", result.PartitionScheme)
+		}
+		if result.Runs == 0 && result.Status != benchmark.StatusOK {
+			fmt.Println(strings.Repeat("-", 40))
+			continue
+		}
 		fmt.Printf("Runs: %d\n", result.Runs)
+		if result.Batch > 1 {
+			fmt.Printf("Invocations per Run: %d\n", result.Batch)
+		}
+		if result.Runs > 1 {
+			fmt.Printf("Precision: ±%.2f%% (95%% CI)\n", result.Precision*100)
+		}
+		if result.Warmup > 0 || result.ReuseInput {
+			fmt.Printf("Warm-up Iterations: %d (reused input: %t)\n", result.Warmup, result.ReuseInput)
+		}
 		fmt.Printf("Mean Duration: %s\n", formatDuration(result.MeanDuration))
 		fmt.Printf("Std Deviation: %s\n", formatDuration(result.StdDeviation))
 		fmt.Printf("Min Duration: %s\n", formatDuration(result.MinDuration))
 		fmt.Printf("Max Duration: %s\n", formatDuration(result.MaxDuration))
-		fmt.Printf("Memory Used: %s\n", formatBytes(result.MemoryUsed))
+		fmt.Printf("Median Duration: %s\n", formatDuration(result.MedianDuration))
+		fmt.Printf("P90 / P95 / P99: %s / %s / %s\n", formatDuration(result.P90Duration), formatDuration(result.P95Duration), formatDuration(result.P99Duration))
+		fmt.Printf("MAD: %s (CV %.1f%%)\n", formatDuration(result.MAD), result.CV*100)
+		fmt.Printf("95%% CI of Mean: %s – %s\n", formatDuration(result.CILower), formatDuration(result.CIUpper))
+		if result.LayoutDuration > 0 {
+			fmt.Printf("Layout Build: %s\n", formatDuration(result.LayoutDuration))
+		}
+		if result.Queries > 0 {
+			fmt.Printf("Queries: %d", result.Queries)
+			if result.QueryDistribution != "" {
+				fmt.Printf(" (%s, %.0f%% hits)", result.QueryDistribution, result.HitRatio*100)
+			}
+			fmt.Println()
+			fmt.Printf("Query Latency: %s\n", formatDuration(result.QueryLatency))
+			fmt.Printf("Throughput: %.0f queries/s\n", result.Throughput)
+		}
+		if result.Operations != nil {
+			fmt.Printf("Comparisons: %d\n", result.Operations.Comparisons)
+			fmt.Printf("Swaps: %d\n", result.Operations.Swaps)
+			fmt.Printf("Reads: %d\n", result.Operations.Reads)
+			fmt.Printf("Writes: %d\n", result.Operations.Writes)
+		}
+		fmt.Printf("Memory Used: %s/op, %d allocs/op\n", formatBytes(result.BytesPerOp), result.AllocsPerOp)
+		if result.PeakHeapMode == benchmark.PeakHeapUntimed {
+			fmt.Printf("Peak Heap: %s (untimed run)\n", formatBytes(result.PeakHeap))
+		}
 		fmt.Println(strings.Repeat("-", 40))
 	}
+	
+	summary := benchmark.Summarize(results)
+	fmt.Printf("\nSummary: %s\n", summary)
+	for _, failure := range summary.Failures {
+		if failure.Status == benchmark.StatusSkipped {
+			continue
+		}
+		
+		cell := fmt.Sprintf("%s, %s, size %d, seed %d", failure.Algorithm, failure.ArrayType, failure.Size, failure.Seed)
+		if failure.PivotStrategy != "" {
+			cell = fmt.Sprintf("%s (%s/%s), %s, size %d, seed %d", failure.Algorithm, failure.PivotStrategy, failure.PartitionScheme, failure.ArrayType, failure.Size, failure.Seed)
+		}
+		fmt.Printf("  %s: %s\n", cell, benchmark.FormatStatus(failure))
+		if failure.Stack != "" {
+			fmt.Println(failure.Stack)
+		}
+	}
 }
 
 func (cli *CLI) runInteractive() {
@@ -221,32 +552,38 @@ func (cli *CLI) runInteractive() {
 
 func (cli *CLI) interactiveSingleBenchmark() {
 	fmt.Println("\nAvailable algorithms:")
-	algorithms := []string{"linear_search", "binary_search", "bubble_sort", "insertion_sort", "merge_sort", "quick_sort", "heap_sort", "native_sort"}
-	for i, alg := range algorithms {
+	names := algorithms.AllNames()
+	for i, alg := range names {
 		fmt.Printf("%d. %s\n", i+1, alg)
 	}
 	
 	var choice int
-	fmt.Print("Select algorithm (1-8): ")
+	fmt.Printf("Select algorithm (1-%d): ", len(names))
 	fmt.Scanln(&choice)
 	
-	if choice < 1 || choice > 8 {
+	if choice < 1 || choice > len(names) {
 		fmt.Println("Invalid choice.")
 		return
 	}
 	
-	algorithm := algorithms[choice-1]
+	algorithm := names[choice-1]
 	
 	fmt.Println("\nArray types:")
-	fmt.Println("1. Random")
-	fmt.Println("2. Sorted")
-	fmt.Println("3. Reverse Sorted")
+	arrayTypes := data.GetAllArrayTypes()
+	for i, arrayType := range arrayTypes {
+		fmt.Printf("%d. %s\n", i+1, data.GetArrayTypeName(arrayType))
+	}
 	
 	var arrayChoice int
-	fmt.Print("Select array type (1-3): ")
+	fmt.Printf("Select array type (1-%d): ", len(arrayTypes))
 	fmt.Scanln(&arrayChoice)
 	
-	arrayType := data.ArrayType(arrayChoice - 1)
+	if arrayChoice < 1 || arrayChoice > len(arrayTypes) {
+		fmt.Println("Invalid choice.")
+		return
+	}
+	
+	arrayType := arrayTypes[arrayChoice-1]
 	
 	var size int
 	fmt.Print("Enter array size: ")
@@ -257,7 +594,14 @@ func (cli *CLI) interactiveSingleBenchmark() {
 	fmt.Scanln(&runs)
 	
 	cli.benchmarkSuite.ClearResults()
-	cli.runSingleBenchmark(algorithm, arrayType, size, runs)
+	cli.runSingleBenchmark(context.Background(), benchmark.BenchmarkConfig{
+		Algorithm: algorithm,
+		ArrayType: arrayType,
+		Size:      size,
+		Runs:      runs,
+		Target:    size / 2,
+		Workload:  benchmark.DefaultSearchWorkload(),
+	})
 	cli.displayResults()
 }
 
@@ -267,10 +611,11 @@ func (cli *CLI) interactiveSearchBenchmarks() {
 	fmt.Scanln(&runs)
 	
 	cli.benchmarkSuite.ClearResults()
-	sizes := []int{1000, 10000, 100000, 1000000}
+	sizes := benchmark.DefaultSizes()
+	ctx := context.Background()
 	
 	fmt.Println("Running search benchmarks...")
-	if err := cli.benchmarkSuite.RunSearchBenchmarks(sizes, runs); err != nil {
+	if err := cli.benchmarkSuite.RunSearchBenchmarks(ctx, sizes, interactiveSuiteConfig(runs)); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
@@ -284,10 +629,11 @@ func (cli *CLI) interactiveSortBenchmarks() {
 	fmt.Scanln(&runs)
 	
 	cli.benchmarkSuite.ClearResults()
-	sizes := []int{1000, 10000, 100000, 1000000}
+	sizes := benchmark.DefaultSizes()
+	ctx := context.Background()
 	
 	fmt.Println("Running sort benchmarks...")
-	if err := cli.benchmarkSuite.RunSortBenchmarks(sizes, runs); err != nil {
+	if err := cli.benchmarkSuite.RunSortBenchmarks(ctx, sizes, interactiveSuiteConfig(runs)); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
@@ -300,10 +646,18 @@ func (cli *CLI) interactiveComprehensiveBenchmark() {
 	fmt.Print("Enter number of runs: ")
 	fmt.Scanln(&runs)
 	
-	cli.runAllBenchmarks(0, runs)
+	cli.runAllBenchmarks(context.Background(), interactiveSuiteConfig(runs))
 	cli.displayResults()
 }
 
+func interactiveSuiteConfig(runs int) benchmark.BenchmarkConfig {
+	return benchmark.BenchmarkConfig{
+		Runs:       runs,
+		Workload:   benchmark.DefaultSearchWorkload(),
+		CellBudget: benchmark.DefaultCellBudget,
+	}
+}
+
 func (cli *CLI) interactiveExport() {
 	results := cli.benchmarkSuite.GetResults()
 	if len(results) == 0 {
@@ -357,15 +711,87 @@ func (cli *CLI) interactiveExport() {
 	}
 }
 
-func (cli *CLI) parseArrayType(arrayType string) data.ArrayType {
-	switch strings.ToLower(arrayType) {
-	case "sorted":
-		return data.Sorted
-	case "reverse":
-		return data.ReverseSorted
-	default:
-		return data.Random
+func algorithmChoices() string {
+	return strings.Join(append(algorithms.AllNames(), "all"), ", ")
+}
+
+func pivotChoices() string {
+	var names []string
+	for _, pivot := range algorithms.GetAllPivotStrategies() {
+		names = append(names, algorithms.GetPivotStrategyName(pivot))
+	}
+	return strings.Join(names, ", ")
+}
+
+func partitionChoices() string {
+	var names []string
+	for _, scheme := range algorithms.GetAllPartitionSchemes() {
+		names = append(names, algorithms.GetPartitionSchemeName(scheme))
+	}
+	return strings.Join(names, ", ")
+}
+
+func queryDistributionChoices() string {
+	var names []string
+	for _, distribution := range data.GetAllQueryDistributions() {
+		names = append(names, data.GetQueryDistributionName(distribution))
+	}
+	return strings.Join(names, ", ")
+}
+
+func parsePivotStrategies(value string) ([]algorithms.PivotStrategy, error) {
+	if strings.EqualFold(value, "all") {
+		return algorithms.GetAllPivotStrategies(), nil
+	}
+	
+	pivot, err := algorithms.ParsePivotStrategy(value)
+	if err != nil {
+		return nil, err
+	}
+	return []algorithms.PivotStrategy{pivot}, nil
+}
+
+func parsePartitionSchemes(value string) ([]algorithms.PartitionScheme, error) {
+	if strings.EqualFold(value, "all") {
+		return algorithms.GetAllPartitionSchemes(), nil
+	}
+	
+	scheme, err := algorithms.ParsePartitionScheme(value)
+	if err != nil {
+		return nil, err
+	}
+	return []algorithms.PartitionScheme{scheme}, nil
+}
+
+func describeRuns(config benchmark.BenchmarkConfig) string {
+	if config.Adaptive.Enabled() {
+		return fmt.Sprintf("adaptive runs to ±%g%%", config.Adaptive.TargetPrecision*100)
+	}
+	return fmt.Sprintf("%d runs", config.Runs)
+}
+
+func elementTypeChoices() string {
+	var names []string
+	for _, elementType := range data.GetAllElementTypes() {
+		names = append(names, data.GetElementTypeName(elementType))
+	}
+	return strings.Join(names, ", ")
+}
+
+func datasetFormatChoices() string {
+	var names []string
+	for _, format := range data.GetAllDatasetFormats() {
+		names = append(names, data.GetDatasetFormatName(format))
+	}
+	return strings.Join(names, ", ")
+}
+
+func arrayTypeChoices() string {
+	var names []string
+	for _, arrayType := range append(data.GetAllArrayTypes(), data.GetAdversarialArrayTypes()...) {
+		names = append(names, data.GetArrayTypeKey(arrayType))
 	}
+	return strings.Join(names, ", ")
 }
 
 func formatDuration(d time.Duration) string {
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"cmp"
+	"slices"
+)
+
+type CompareSort func(arr []int, compare func(a, b int) int) []int
+
+func AntiQuicksortArray(size int, target CompareSort) []int {
+	if target == nil {
+		target = func(arr []int, compare func(a, b int) int) []int {
+			slices.SortFunc(arr, compare)
+			return arr
+		}
+	}
+	
+	gas := size
+	values := make([]int, size)
+	items := make([]int, size)
+	for i := range values {
+		values[i] = gas
+		items[i] = i
+	}
+	
+	solid := 0
+	candidate := 0
+	freeze := func(item int) {
+		values[item] = solid
+		solid++
+	}
+	
+	target(items, func(x, y int) int {
+		if values[x] == gas && values[y] == gas {
+			if x == candidate {
+				freeze(x)
+			} else {
+				freeze(y)
+			}
+		}
+		if values[x] == gas {
+			candidate = x
+		} else if values[y] == gas {
+			candidate = y
+		}
+		return cmp.Compare(values[x], values[y])
+	})
+	
+	for i := range values {
+		if values[i] == gas {
+			freeze(i)
+		}
+	}
+	return values
+}
+
+func MedianOfThreeKillerArray(size int) []int {
+	arr := make([]int, size)
+	n := size - size%4
+	k := n / 2
+	for i := 1; i <= k; i++ {
+		if i%2 == 1 {
+			arr[i-1] = i - 1
+			arr[i] = k + i - 1
+		}
+		arr[k+i-1] = 2*i - 1
+	}
+	for i := n; i < size; i++ {
+		arr[i] = i
+	}
+	return arr
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type DatasetFormat int
+
+const (
+	AutoFormat DatasetFormat = iota
+	LinesFormat
+	CSVFormat
+	Int32Format
+	Int64Format
+)
+
+type Dataset struct {
+	Name     string `json:"name"`
+	Format   string `json:"format"`
+	Size     int    `json:"size"`
+	Checksum string `json:"checksum"`
+	Values   []int  `json:"-"`
+}
+
+type DatasetOptions struct {
+	Format DatasetFormat
+	Column string
+}
+
+func LoadDataset(path string, options DatasetOptions) (*Dataset, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDataset(filepath.Base(path), content, options)
+}
+
+func ParseDataset(name string, content []byte, options DatasetOptions) (*Dataset, error) {
+	format := options.Format
+	if format == AutoFormat {
+		format = DetectDatasetFormat(name)
+	}
+	
+	var values []int
+	var err error
+	switch format {
+	case CSVFormat:
+		values, err = parseCSVColumn(content, options.Column)
+	case Int32Format:
+		values, err = parseBinary(content, 4)
+	case Int64Format:
+		values, err = parseBinary(content, 8)
+	default:
+		format = LinesFormat
+		values, err = parseLines(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s: dataset contains no values", name)
+	}
+	
+	sum := sha256.Sum256(content)
+	return &Dataset{
+		Name:     name,
+		Format:   GetDatasetFormatName(format),
+		Size:     len(values),
+		Checksum: "sha256:" + hex.EncodeToString(sum[:]),
+		Values:   values,
+	}, nil
+}
+
+func DetectDatasetFormat(name string) DatasetFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return CSVFormat
+	case ".i32", ".int32":
+		return Int32Format
+	case ".i64", ".int64":
+		return Int64Format
+	default:
+		return LinesFormat
+	}
+}
+
+func parseLines(content []byte) ([]int, error) {
+	var values []int
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		value, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid integer %q", line, text)
+		}
+		values = append(values, value)
+	}
+	return values, scanner.Err()
+}
+
+func parseCSVColumn(content []byte, column string) ([]int, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	
+	index := 0
+	byName := false
+	if column = strings.TrimSpace(column); column != "" {
+		parsed, err := strconv.Atoi(column)
+		if err != nil {
+			byName = true
+		} else if parsed < 0 {
+			return nil, fmt.Errorf("invalid column index: %d", parsed)
+		} else {
+			index = parsed
+		}
+	}
+	
+	var values []int
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		
+		if row == 1 && byName {
+			index = -1
+			for i, field := range record {
+				if strings.EqualFold(strings.TrimSpace(field), column) {
+					index = i
+				}
+			}
+			if index == -1 {
+				return nil, fmt.Errorf("column %q not found in header", column)
+			}
+			continue
+		}
+		if index >= len(record) {
+			return nil, fmt.Errorf("row %d: missing column %d", row, index)
+		}
+		
+		field := strings.TrimSpace(record[index])
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			if row == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("row %d: invalid integer %q", row, field)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func parseBinary(content []byte, width int) ([]int, error) {
+	if len(content)%width != 0 {
+		return nil, fmt.Errorf("file size %d is not a multiple of %d bytes", len(content), width)
+	}
+	
+	values := make([]int, len(content)/width)
+	for i := range values {
+		chunk := content[i*width : (i+1)*width]
+		if width == 4 {
+			values[i] = int(int32(binary.LittleEndian.Uint32(chunk)))
+		} else {
+			values[i] = int(int64(binary.LittleEndian.Uint64(chunk)))
+		}
+	}
+	return values, nil
+}
+
+func GetDatasetFormatName(format DatasetFormat) string {
+	switch format {
+	case AutoFormat:
+		return "auto"
+	case LinesFormat:
+		return "lines"
+	case CSVFormat:
+		return "csv"
+	case Int32Format:
+		return "int32"
+	case Int64Format:
+		return "int64"
+	default:
+		return "unknown"
+	}
+}
+
+func GetAllDatasetFormats() []DatasetFormat {
+	return []DatasetFormat{AutoFormat, LinesFormat, CSVFormat, Int32Format, Int64Format}
+}
+
+func ParseDatasetFormat(name string) (DatasetFormat, error) {
+	for _, format := range GetAllDatasetFormats() {
+		if strings.EqualFold(name, GetDatasetFormatName(format)) {
+			return format, nil
+		}
+	}
+	return AutoFormat, fmt.Errorf("unknown dataset format: %s", name)
+}
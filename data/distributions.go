@@ -0,0 +1,166 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ArrayParams struct {
+	Swaps           int
+	Unique          int
+	Teeth           int
+	TailFraction    float64
+	StdDev          float64
+	ZipfExponent    float64
+	ExponentialMean float64
+	StringLength    int
+	Alphabet        string
+	NaNFraction     float64
+}
+
+const (
+	defaultUnique       = 10
+	defaultTeeth        = 8
+	defaultTailFraction = 0.1
+	defaultZipfExponent = 1.1
+	defaultStringLength = 16
+	defaultAlphabet     = "abcdefghijklmnopqrstuvwxyz"
+)
+
+func DefaultArrayParams(size int) ArrayParams {
+	return ArrayParams{}.withDefaults(size)
+}
+
+func (p ArrayParams) withDefaults(size int) ArrayParams {
+	if p.Swaps <= 0 {
+		p.Swaps = size / 100
+		if p.Swaps < 1 {
+			p.Swaps = 1
+		}
+	}
+	if p.Unique <= 0 {
+		p.Unique = defaultUnique
+	}
+	if p.Teeth <= 0 {
+		p.Teeth = defaultTeeth
+	}
+	if p.TailFraction <= 0 || p.TailFraction > 1 {
+		p.TailFraction = defaultTailFraction
+	}
+	if p.StdDev <= 0 {
+		p.StdDev = float64(size) / 8
+	}
+	if p.ZipfExponent <= 1 {
+		p.ZipfExponent = defaultZipfExponent
+	}
+	if p.ExponentialMean <= 0 {
+		p.ExponentialMean = float64(size) / 4
+	}
+	if p.StringLength <= 0 {
+		p.StringLength = defaultStringLength
+	}
+	if p.Alphabet == "" {
+		p.Alphabet = defaultAlphabet
+	}
+	if p.NaNFraction < 0 || p.NaNFraction > 1 {
+		p.NaNFraction = 0
+	}
+	return p
+}
+
+func ParseArrayParams(value string) (ArrayParams, error) {
+	var params ArrayParams
+	if strings.TrimSpace(value) == "" {
+		return params, nil
+	}
+	
+	for _, pair := range strings.Split(value, ",") {
+		key, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return params, fmt.Errorf("invalid array parameter %q, expected key=value", pair)
+		}
+		
+		var err error
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "swaps":
+			params.Swaps, err = parsePositiveInt(raw)
+		case "unique":
+			params.Unique, err = parsePositiveInt(raw)
+		case "teeth":
+			params.Teeth, err = parsePositiveInt(raw)
+		case "tail_fraction":
+			params.TailFraction, err = parseFloatInRange(raw, 0, 1)
+		case "stddev":
+			params.StdDev, err = parseFloatInRange(raw, 0, -1)
+		case "zipf_exponent":
+			params.ZipfExponent, err = parseFloatInRange(raw, 1, -1)
+		case "exp_mean":
+			params.ExponentialMean, err = parseFloatInRange(raw, 0, -1)
+		case "string_length":
+			params.StringLength, err = parsePositiveInt(raw)
+		case "alphabet":
+			params.Alphabet = strings.TrimSpace(raw)
+			if params.Alphabet == "" {
+				err = fmt.Errorf("must not be empty")
+			}
+		case "nan_fraction":
+			params.NaNFraction, err = parseFloatInRange(raw, 0, 1)
+		default:
+			return params, fmt.Errorf("unknown array parameter: %s", key)
+		}
+		if err != nil {
+			return params, fmt.Errorf("invalid value for %s: %v", key, err)
+		}
+	}
+	
+	return params, nil
+}
+
+func FormatArrayParams(arrayType ArrayType, size int, params ArrayParams) string {
+	params = params.withDefaults(size)
+	
+	switch arrayType {
+	case NearlySorted:
+		return fmt.Sprintf("swaps=%d", params.Swaps)
+	case FewUnique:
+		return fmt.Sprintf("unique=%d", params.Unique)
+	case Sawtooth:
+		return fmt.Sprintf("teeth=%d", params.Teeth)
+	case SortedRandomTail:
+		return fmt.Sprintf("tail_fraction=%g", params.TailFraction)
+	case Gaussian:
+		return fmt.Sprintf("stddev=%g", params.StdDev)
+	case Zipf:
+		return fmt.Sprintf("zipf_exponent=%g", params.ZipfExponent)
+	case Exponential:
+		return fmt.Sprintf("exp_mean=%g", params.ExponentialMean)
+	default:
+		return ""
+	}
+}
+
+func parsePositiveInt(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("must be positive: %d", n)
+	}
+	return n, nil
+}
+
+func parseFloatInRange(value string, min, max float64) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if f <= min || (max > min && f > max) {
+		if max > min {
+			return 0, fmt.Errorf("must be in (%g, %g]: %g", min, max, f)
+		}
+		return 0, fmt.Errorf("must be greater than %g: %g", min, f)
+	}
+	return f, nil
+}
@@ -0,0 +1,214 @@
+package data
+
+import (
+	"algorithm-benchmark/algorithms"
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+type ElementType int
+
+const (
+	IntElements ElementType = iota
+	Int32Elements
+	Int64Elements
+	Float64Elements
+	StringElements
+	RecordElements
+)
+
+func ToInt32s(keys []int) ([]int32, error) {
+	arr := make([]int32, len(keys))
+	for i, key := range keys {
+		if key < math.MinInt32 || key > math.MaxInt32 {
+			return nil, fmt.Errorf("value %d at index %d is out of range for int32", key, i)
+		}
+		arr[i] = int32(key)
+	}
+	return arr, nil
+}
+
+func ToInt64s(keys []int) []int64 {
+	arr := make([]int64, len(keys))
+	for i, key := range keys {
+		arr[i] = int64(key)
+	}
+	return arr
+}
+
+func ToFloat64s(keys []int, params ArrayParams, rng *rand.Rand) []float64 {
+	arr := make([]float64, len(keys))
+	for i, key := range keys {
+		arr[i] = float64(key)
+	}
+	if params.NaNFraction > 0 {
+		for i := range arr {
+			if rng.Float64() < params.NaNFraction {
+				arr[i] = math.NaN()
+			}
+		}
+	}
+	return arr
+}
+
+func ToStrings(keys []int, params ArrayParams, rng *rand.Rand) []string {
+	params = params.withDefaults(len(keys))
+	alphabet := []rune(params.Alphabet)
+	
+	distinct := distinctSorted(keys)
+	words := make([]string, len(distinct))
+	word := make([]rune, params.StringLength)
+	for i := range words {
+		for j := range word {
+			word[j] = alphabet[rng.Intn(len(alphabet))]
+		}
+		words[i] = string(word)
+	}
+	sort.Strings(words)
+	
+	arr := make([]string, len(keys))
+	for i, key := range keys {
+		arr[i] = words[sort.SearchInts(distinct, key)]
+	}
+	return arr
+}
+
+func ToRecords(keys []int) []algorithms.Record {
+	arr := make([]algorithms.Record, len(keys))
+	for i, key := range keys {
+		arr[i].Key = int64(key)
+		arr[i].Payload[0] = int64(i)
+	}
+	return arr
+}
+
+func VerifySortingFunc[T comparable](original, sorted []T, compare func(a, b T) int) bool {
+	if len(original) != len(sorted) {
+		return false
+	}
+	
+	for i := 1; i < len(sorted); i++ {
+		if compare(sorted[i-1], sorted[i]) > 0 {
+			return false
+		}
+	}
+	
+	counts := make(map[T]int, len(original))
+	for _, v := range original {
+		counts[v]++
+	}
+	for _, v := range sorted {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
+
+func VerifySortingFloat64(original, sorted []float64) bool {
+	if len(original) != len(sorted) {
+		return false
+	}
+	
+	for i := 1; i < len(sorted); i++ {
+		if cmp.Compare(sorted[i-1], sorted[i]) > 0 {
+			return false
+		}
+	}
+	
+	canonical := func(v float64) uint64 {
+		if math.IsNaN(v) {
+			return math.Float64bits(math.NaN())
+		}
+		return math.Float64bits(v)
+	}
+	counts := make(map[uint64]int, len(original))
+	for _, v := range original {
+		counts[canonical(v)]++
+	}
+	for _, v := range sorted {
+		if counts[canonical(v)] == 0 {
+			return false
+		}
+		counts[canonical(v)]--
+	}
+	return true
+}
+
+func VerifyStable(original, sorted []algorithms.Record) bool {
+	if len(original) != len(sorted) {
+		return false
+	}
+	
+	for i := 1; i < len(sorted); i++ {
+		if algorithms.CompareRecords(sorted[i-1], sorted[i]) > 0 {
+			return false
+		}
+	}
+	
+	groups := make(map[int64][]algorithms.Record)
+	for _, record := range original {
+		groups[record.Key] = append(groups[record.Key], record)
+	}
+	for _, record := range sorted {
+		group := groups[record.Key]
+		if len(group) == 0 || group[0] != record {
+			return false
+		}
+		groups[record.Key] = group[1:]
+	}
+	return true
+}
+
+func FormatElementParams(elementType ElementType, size int, params ArrayParams) string {
+	params = params.withDefaults(size)
+	
+	switch elementType {
+	case Float64Elements:
+		if params.NaNFraction > 0 {
+			return fmt.Sprintf("nan_fraction=%g", params.NaNFraction)
+		}
+		return ""
+	case StringElements:
+		return fmt.Sprintf("string_length=%d,alphabet=%s", params.StringLength, params.Alphabet)
+	default:
+		return ""
+	}
+}
+
+func GetElementTypeName(elementType ElementType) string {
+	switch elementType {
+	case IntElements:
+		return "int"
+	case Int32Elements:
+		return "int32"
+	case Int64Elements:
+		return "int64"
+	case Float64Elements:
+		return "float64"
+	case StringElements:
+		return "string"
+	case RecordElements:
+		return "record"
+	default:
+		return "unknown"
+	}
+}
+
+func GetAllElementTypes() []ElementType {
+	return []ElementType{IntElements, Int32Elements, Int64Elements, Float64Elements, StringElements, RecordElements}
+}
+
+func ParseElementType(name string) (ElementType, error) {
+	for _, elementType := range GetAllElementTypes() {
+		if strings.EqualFold(name, GetElementTypeName(elementType)) {
+			return elementType, nil
+		}
+	}
+	return IntElements, fmt.Errorf("unknown element type: %s", name)
+}
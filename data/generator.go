@@ -1,9 +1,11 @@
 package data
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
 	"sort"
-	"time"
+	"strings"
 )
 
 type ArrayType int
@@ -12,17 +14,38 @@ const (
 	Random ArrayType = iota
 	Sorted
 	ReverseSorted
+	NearlySorted
+	FewUnique
+	AllEqual
+	OrganPipe
+	Sawtooth
+	SortedRandomTail
+	Gaussian
+	Zipf
+	Exponential
+	MedianOfThreeKiller
+	AntiQuicksort
 )
 
 func GenerateArray(size int, arrayType ArrayType) []int {
-	rand.Seed(time.Now().UnixNano())
-	
+	return GenerateArrayWithParams(size, arrayType, ArrayParams{})
+}
+
+func GenerateArrayWithParams(size int, arrayType ArrayType, params ArrayParams) []int {
+	return GenerateArrayWithRand(size, arrayType, params, NewRand())
+}
+
+func GenerateArrayWithRand(size int, arrayType ArrayType, params ArrayParams, rng *rand.Rand) []int {
 	arr := make([]int, size)
+	if size == 0 {
+		return arr
+	}
+	params = params.withDefaults(size)
 	
 	switch arrayType {
 	case Random:
 		for i := 0; i < size; i++ {
-			arr[i] = rand.Intn(size * 2)
+			arr[i] = rng.Intn(size * 2)
 		}
 	case Sorted:
 		for i := 0; i < size; i++ {
@@ -32,11 +55,78 @@ func GenerateArray(size int, arrayType ArrayType) []int {
 		for i := 0; i < size; i++ {
 			arr[i] = size - i - 1
 		}
+	case NearlySorted:
+		for i := 0; i < size; i++ {
+			arr[i] = i
+		}
+		for k := 0; k < params.Swaps; k++ {
+			i, j := rng.Intn(size), rng.Intn(size)
+			arr[i], arr[j] = arr[j], arr[i]
+		}
+	case FewUnique:
+		spacing := 2 * size / params.Unique
+		if spacing < 1 {
+			spacing = 1
+		}
+		for i := 0; i < size; i++ {
+			arr[i] = rng.Intn(params.Unique) * spacing
+		}
+	case AllEqual:
+		for i := 0; i < size; i++ {
+			arr[i] = size
+		}
+	case OrganPipe:
+		for i := 0; i < size; i++ {
+			if i < size-1-i {
+				arr[i] = i
+			} else {
+				arr[i] = size - 1 - i
+			}
+		}
+	case Sawtooth:
+		period := (size + params.Teeth - 1) / params.Teeth
+		for i := 0; i < size; i++ {
+			arr[i] = i % period
+		}
+	case SortedRandomTail:
+		head := size - int(math.Round(float64(size)*params.TailFraction))
+		for i := 0; i < size; i++ {
+			if i < head {
+				arr[i] = i
+			} else {
+				arr[i] = rng.Intn(size * 2)
+			}
+		}
+	case Gaussian:
+		for i := 0; i < size; i++ {
+			arr[i] = size + int(math.Round(rng.NormFloat64()*params.StdDev))
+		}
+	case Zipf:
+		zipf := rand.NewZipf(rng, params.ZipfExponent, 1, uint64(size*2))
+		for i := 0; i < size; i++ {
+			arr[i] = int(zipf.Uint64())
+		}
+	case Exponential:
+		for i := 0; i < size; i++ {
+			arr[i] = int(rng.ExpFloat64() * params.ExponentialMean)
+		}
+	case MedianOfThreeKiller:
+		arr = MedianOfThreeKillerArray(size)
+	case AntiQuicksort:
+		arr = AntiQuicksortArray(size, nil)
 	}
 	
 	return arr
 }
 
+func NewRand() *rand.Rand {
+	return rand.New(rand.NewSource(rand.Int63()))
+}
+
+func NewSeededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
 func GetArrayTypeName(arrayType ArrayType) string {
 	switch arrayType {
 	case Random:
@@ -45,13 +135,87 @@ func GetArrayTypeName(arrayType ArrayType) string {
 		return "Sorted"
 	case ReverseSorted:
 		return "Reverse Sorted"
+	case NearlySorted:
+		return "Nearly Sorted"
+	case FewUnique:
+		return "Few Unique"
+	case AllEqual:
+		return "All Equal"
+	case OrganPipe:
+		return "Organ Pipe"
+	case Sawtooth:
+		return "Sawtooth"
+	case SortedRandomTail:
+		return "Sorted Random Tail"
+	case Gaussian:
+		return "Gaussian"
+	case Zipf:
+		return "Zipf"
+	case Exponential:
+		return "Exponential"
+	case MedianOfThreeKiller:
+		return "Median-of-3 Killer"
+	case AntiQuicksort:
+		return "Anti-Quicksort"
 	default:
 		return "Unknown"
 	}
 }
 
+func GetArrayTypeKey(arrayType ArrayType) string {
+	switch arrayType {
+	case Random:
+		return "random"
+	case Sorted:
+		return "sorted"
+	case ReverseSorted:
+		return "reverse"
+	case NearlySorted:
+		return "nearly_sorted"
+	case FewUnique:
+		return "few_unique"
+	case AllEqual:
+		return "all_equal"
+	case OrganPipe:
+		return "organ_pipe"
+	case Sawtooth:
+		return "sawtooth"
+	case SortedRandomTail:
+		return "sorted_random_tail"
+	case Gaussian:
+		return "gaussian"
+	case Zipf:
+		return "zipf"
+	case Exponential:
+		return "exponential"
+	case MedianOfThreeKiller:
+		return "median_of_three_killer"
+	case AntiQuicksort:
+		return "anti_quicksort"
+	default:
+		return "unknown"
+	}
+}
+
 func GetAllArrayTypes() []ArrayType {
-	return []ArrayType{Random, Sorted, ReverseSorted}
+	return []ArrayType{
+		Random, Sorted, ReverseSorted, NearlySorted, FewUnique, AllEqual,
+		OrganPipe, Sawtooth, SortedRandomTail, Gaussian, Zipf, Exponential,
+		MedianOfThreeKiller,
+	}
+}
+
+func GetAdversarialArrayTypes() []ArrayType {
+	return []ArrayType{AntiQuicksort}
+}
+
+func ParseArrayType(name string) (ArrayType, error) {
+	for _, arrayType := range append(GetAllArrayTypes(), GetAdversarialArrayTypes()...) {
+		if strings.EqualFold(name, GetArrayTypeKey(arrayType)) || strings.EqualFold(name, GetArrayTypeName(arrayType)) {
+			return arrayType, nil
+		}
+	}
+	return Random, fmt.Errorf("unknown array type: %s", name)
 }
 
 func IsSorted(arr []int) bool {
@@ -79,3 +243,32 @@ func VerifySorting(original, sorted []int) bool {
 	}
 	return true
 }
+
+
+func VerifySelection(original []int, k, selected int, ok bool) bool {
+	if k < 0 || k >= len(original) {
+		return !ok
+	}
+	if !ok {
+		return false
+	}
+	
+	originalCopy := make([]int, len(original))
+	copy(originalCopy, original)
+	sort.Ints(originalCopy)
+	
+	return originalCopy[k] == selected
+}
+
+func VerifySearch(arr []int, target, index int) bool {
+	if index == -1 {
+		for _, v := range arr {
+			if v == target {
+				return false
+			}
+		}
+		return true
+	}
+	
+	return index >= 0 && index < len(arr) && arr[index] == target
+}
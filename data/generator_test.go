@@ -1,6 +1,15 @@
 package data
 
 import (
+	"algorithm-benchmark/algorithms"
+	"cmp"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +49,9 @@ func TestGetArrayTypeName(t *testing.T) {
 		{Random, "Random"},
 		{Sorted, "Sorted"},
 		{ReverseSorted, "Reverse Sorted"},
+		{NearlySorted, "Nearly Sorted"},
+		{SortedRandomTail, "Sorted Random Tail"},
+		{Exponential, "Exponential"},
 		{ArrayType(999), "Unknown"},
 	}
 	
@@ -53,7 +65,11 @@ func TestGetArrayTypeName(t *testing.T) {
 
 func TestGetAllArrayTypes(t *testing.T) {
 	arrayTypes := GetAllArrayTypes()
-	expected := []ArrayType{Random, Sorted, ReverseSorted}
+	expected := []ArrayType{
+		Random, Sorted, ReverseSorted, NearlySorted, FewUnique, AllEqual,
+		OrganPipe, Sawtooth, SortedRandomTail, Gaussian, Zipf, Exponential,
+		MedianOfThreeKiller,
+	}
 	
 	if len(arrayTypes) != len(expected) {
 		t.Errorf("Expected %d array types, got %d", len(expected), len(arrayTypes))
@@ -66,6 +82,189 @@ func TestGetAllArrayTypes(t *testing.T) {
 	}
 }
 
+func TestGenerateDistributions(t *testing.T) {
+	const size = 1000
+	
+	for _, arrayType := range GetAllArrayTypes() {
+		if arr := GenerateArray(size, arrayType); len(arr) != size {
+			t.Errorf("%s: expected length %d, got %d", GetArrayTypeName(arrayType), size, len(arr))
+		}
+		if arr := GenerateArray(0, arrayType); len(arr) != 0 {
+			t.Errorf("%s: expected an empty array", GetArrayTypeName(arrayType))
+		}
+	}
+	
+	arr := GenerateArrayWithParams(size, NearlySorted, ArrayParams{Swaps: 5})
+	misplaced := 0
+	for i, v := range arr {
+		if v != i {
+			misplaced++
+		}
+	}
+	if misplaced == 0 || misplaced > 10 {
+		t.Errorf("NearlySorted with 5 swaps misplaced %d elements", misplaced)
+	}
+	
+	if distinct := len(distinctSorted(GenerateArrayWithParams(size, FewUnique, ArrayParams{Unique: 4}))); distinct > 4 {
+		t.Errorf("FewUnique with 4 values produced %d distinct values", distinct)
+	}
+	
+	if distinct := len(distinctSorted(GenerateArray(size, AllEqual))); distinct != 1 {
+		t.Errorf("AllEqual produced %d distinct values", distinct)
+	}
+	
+	arr = GenerateArray(size, OrganPipe)
+	for i := 1; i < size; i++ {
+		if (i < size/2 && arr[i] < arr[i-1]) || (i > size/2 && arr[i] > arr[i-1]) {
+			t.Errorf("OrganPipe is not ascending then descending at index %d", i)
+			break
+		}
+	}
+	
+	arr = GenerateArrayWithParams(size, Sawtooth, ArrayParams{Teeth: 4})
+	descents := 0
+	for i := 1; i < size; i++ {
+		if arr[i] < arr[i-1] {
+			descents++
+		}
+	}
+	if descents != 3 {
+		t.Errorf("Sawtooth with 4 teeth has %d descents, expected 3", descents)
+	}
+	
+	arr = GenerateArrayWithParams(size, SortedRandomTail, ArrayParams{TailFraction: 0.2})
+	if !IsSorted(arr[:800]) {
+		t.Error("SortedRandomTail head should be sorted")
+	}
+	
+	for _, arrayType := range []ArrayType{Zipf, Exponential} {
+		for _, v := range GenerateArray(size, arrayType) {
+			if v < 0 {
+				t.Errorf("%s produced a negative value %d", GetArrayTypeName(arrayType), v)
+				break
+			}
+		}
+	}
+	
+	arr = GenerateArrayWithParams(size, Gaussian, ArrayParams{StdDev: 10})
+	sum := 0
+	for _, v := range arr {
+		sum += v
+	}
+	if mean := sum / size; mean < size-5 || mean > size+5 {
+		t.Errorf("Gaussian mean = %d, expected close to %d", mean, size)
+	}
+}
+
+func TestAdversarialArrays(t *testing.T) {
+	for _, size := range []int{0, 1, 7, 100, 101} {
+		if arr := MedianOfThreeKillerArray(size); !isPermutation(arr) {
+			t.Errorf("Median-of-3 killer of size %d is not a permutation: %v", size, arr)
+		}
+		if arr := AntiQuicksortArray(size, nil); !isPermutation(arr) {
+			t.Errorf("Anti-quicksort input of size %d is not a permutation: %v", size, arr)
+		}
+	}
+	
+	const size = 500
+	comparisons := 0
+	firstPivotQuicksort := func(arr []int, compare func(a, b int) int) []int {
+		var sortRange func(low, high int)
+		sortRange = func(low, high int) {
+			if low >= high {
+				return
+			}
+			i := low
+			for j := low + 1; j <= high; j++ {
+				comparisons++
+				if compare(arr[j], arr[low]) < 0 {
+					i++
+					arr[i], arr[j] = arr[j], arr[i]
+				}
+			}
+			arr[low], arr[i] = arr[i], arr[low]
+			sortRange(low, i-1)
+			sortRange(i+1, high)
+		}
+		sortRange(0, len(arr)-1)
+		return arr
+	}
+	
+	arr := AntiQuicksortArray(size, firstPivotQuicksort)
+	if !isPermutation(arr) {
+		t.Fatal("Anti-quicksort input is not a permutation")
+	}
+	
+	comparisons = 0
+	firstPivotQuicksort(arr, func(a, b int) int { return a - b })
+	if expected := size * (size - 1) / 2; comparisons != expected {
+		t.Errorf("Expected the adversary to force %d comparisons, got %d", expected, comparisons)
+	}
+}
+
+func isPermutation(arr []int) bool {
+	seen := make([]bool, len(arr))
+	for _, v := range arr {
+		if v < 0 || v >= len(arr) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+func TestParseArrayType(t *testing.T) {
+	for _, arrayType := range append(GetAllArrayTypes(), GetAdversarialArrayTypes()...) {
+		for _, name := range []string{GetArrayTypeKey(arrayType), GetArrayTypeName(arrayType)} {
+			parsed, err := ParseArrayType(name)
+			if err != nil || parsed != arrayType {
+				t.Errorf("ParseArrayType(%q) = %d, %v", name, parsed, err)
+			}
+		}
+	}
+	
+	if _, err := ParseArrayType("triangular"); err == nil {
+		t.Error("Expected error for unknown array type")
+	}
+}
+
+func TestParseArrayParams(t *testing.T) {
+	params, err := ParseArrayParams("swaps=10, unique=5,teeth=3,tail_fraction=0.25,stddev=2.5,zipf_exponent=1.5,exp_mean=40")
+	if err != nil {
+		t.Fatalf("ParseArrayParams failed: %v", err)
+	}
+	expected := ArrayParams{Swaps: 10, Unique: 5, Teeth: 3, TailFraction: 0.25, StdDev: 2.5, ZipfExponent: 1.5, ExponentialMean: 40}
+	if params != expected {
+		t.Errorf("ParseArrayParams = %+v, expected %+v", params, expected)
+	}
+	
+	if params, err := ParseArrayParams(""); err != nil || params != (ArrayParams{}) {
+		t.Errorf("ParseArrayParams(\"\") = %+v, %v", params, err)
+	}
+	
+	params, err = ParseArrayParams("string_length=4,alphabet=ACGT,nan_fraction=0.1")
+	if err != nil {
+		t.Fatalf("ParseArrayParams failed: %v", err)
+	}
+	expected = ArrayParams{StringLength: 4, Alphabet: "ACGT", NaNFraction: 0.1}
+	if params != expected {
+		t.Errorf("ParseArrayParams = %+v, expected %+v", params, expected)
+	}
+	
+	for _, value := range []string{"swaps", "swaps=-1", "tail_fraction=2", "zipf_exponent=1", "depth=3", "alphabet=", "nan_fraction=1.5"} {
+		if _, err := ParseArrayParams(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+	
+	if got := FormatArrayParams(NearlySorted, 1000, ArrayParams{}); got != "swaps=10" {
+		t.Errorf("FormatArrayParams(NearlySorted) = %q, expected swaps=10", got)
+	}
+	if got := FormatArrayParams(Random, 1000, ArrayParams{}); got != "" {
+		t.Errorf("FormatArrayParams(Random) = %q, expected empty", got)
+	}
+}
+
 func TestIsSorted(t *testing.T) {
 	tests := []struct {
 		arr      []int
@@ -113,8 +312,8 @@ func TestVerifySorting(t *testing.T) {
 }
 
 func TestGenerateArrayUniqueness(t *testing.T) {
-	arr1 := GenerateArray(1000, Random)
-	arr2 := GenerateArray(1000, Random)
+	arr1 := GenerateArrayWithRand(1000, Random, ArrayParams{}, NewSeededRand(1))
+	arr2 := GenerateArrayWithRand(1000, Random, ArrayParams{}, NewSeededRand(2))
 	
 	identical := true
 	for i := 0; i < len(arr1) && i < len(arr2); i++ {
@@ -129,6 +328,23 @@ func TestGenerateArrayUniqueness(t *testing.T) {
 	}
 }
 
+func TestGenerateArrayDeterministic(t *testing.T) {
+	for _, arrayType := range GetAllArrayTypes() {
+		arr1 := GenerateArrayWithRand(500, arrayType, ArrayParams{}, NewSeededRand(42))
+		arr2 := GenerateArrayWithRand(500, arrayType, ArrayParams{}, NewSeededRand(42))
+		if !reflect.DeepEqual(arr1, arr2) {
+			t.Errorf("%s arrays differ for the same seed", GetArrayTypeName(arrayType))
+		}
+	}
+	
+	arr := GenerateArrayWithRand(500, Random, ArrayParams{}, NewSeededRand(42))
+	queries1 := GenerateQueriesWithRand(arr, 200, 0.5, ZipfQueries, NewSeededRand(7))
+	queries2 := GenerateQueriesWithRand(arr, 200, 0.5, ZipfQueries, NewSeededRand(7))
+	if !reflect.DeepEqual(queries1, queries2) {
+		t.Error("Queries differ for the same seed")
+	}
+}
+
 func TestSortedArrayValues(t *testing.T) {
 	arr := GenerateArray(100, Sorted)
 	
@@ -149,3 +365,323 @@ func TestReverseSortedArrayValues(t *testing.T) {
 		}
 	}
 }
+
+
+func TestVerifySelection(t *testing.T) {
+	tests := []struct {
+		original []int
+		k        int
+		selected int
+		ok       bool
+		expected bool
+	}{
+		{[]int{3, 1, 2}, 0, 1, true, true},
+		{[]int{3, 1, 2}, 2, 3, true, true},
+		{[]int{5, 2, 8, 1, 9}, 2, 5, true, true},
+		{[]int{5, 2, 8, 1, 9}, 2, 8, true, false},
+		{[]int{-1, 2, 3}, 0, -1, true, true},
+		{[]int{-1, 2, 3}, 0, -1, false, false},
+		{[]int{1, 2, 3}, 3, 0, false, true},
+		{[]int{1, 2, 3}, -1, 1, true, false},
+	}
+	
+	for _, test := range tests {
+		result := VerifySelection(test.original, test.k, test.selected, test.ok)
+		if result != test.expected {
+			t.Errorf("VerifySelection(%v, %d, %d, %t) = %t, expected %t", test.original, test.k, test.selected, test.ok, result, test.expected)
+		}
+	}
+}
+
+func TestVerifySearch(t *testing.T) {
+	arr := []int{4, 2, 7, 2, 9}
+	
+	tests := []struct {
+		target   int
+		index    int
+		expected bool
+	}{
+		{7, 2, true},
+		{2, 1, true},
+		{2, 3, true},
+		{2, 0, false},
+		{5, -1, true},
+		{9, -1, false},
+		{4, 5, false},
+	}
+	
+	for _, test := range tests {
+		result := VerifySearch(arr, test.target, test.index)
+		if result != test.expected {
+			t.Errorf("VerifySearch(%v, %d, %d) = %t, expected %t", arr, test.target, test.index, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateQueries(t *testing.T) {
+	arr := []int{9, 3, 3, 14, 0, 7, 22, 7}
+	present := make(map[int]bool)
+	for _, v := range arr {
+		present[v] = true
+	}
+	
+	for _, distribution := range GetAllQueryDistributions() {
+		for _, hitRatio := range []float64{0, 0.25, 0.5, 1} {
+			queries := GenerateQueries(arr, 100, hitRatio, distribution)
+			if len(queries) != 100 {
+				t.Fatalf("Expected 100 queries, got %d", len(queries))
+			}
+			
+			hits := 0
+			for _, q := range queries {
+				if present[q] {
+					hits++
+				}
+			}
+			if expected := int(100 * hitRatio); hits != expected {
+				t.Errorf("%s queries with hit ratio %.2f: got %d hits, expected %d",
+					GetQueryDistributionName(distribution), hitRatio, hits, expected)
+			}
+		}
+	}
+	
+	for _, edge := range [][]int{
+		{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt},
+		{math.MinInt, math.MinInt + 1, math.MinInt + 2},
+		{math.MinInt, math.MinInt + 1, math.MaxInt - 1, math.MaxInt},
+	} {
+		for _, q := range GenerateQueries(edge, 20, 0, UniformQueries) {
+			if slices.Contains(edge, q) {
+				t.Errorf("Miss query %d for %v is a hit", q, edge)
+			}
+		}
+	}
+	
+	sequential := GenerateQueries([]int{5, 1, 3}, 4, 1, SequentialQueries)
+	for i, expected := range []int{1, 3, 5, 1} {
+		if sequential[i] != expected {
+			t.Errorf("Sequential queries = %v, expected [1 3 5 1]", sequential)
+			break
+		}
+	}
+}
+
+func TestParseDataset(t *testing.T) {
+	expected := []int{5, -3, 9, 1}
+	
+	int32Data := make([]byte, 0, 16)
+	int64Data := make([]byte, 0, 32)
+	for _, v := range expected {
+		int32Data = binary.LittleEndian.AppendUint32(int32Data, uint32(int32(v)))
+		int64Data = binary.LittleEndian.AppendUint64(int64Data, uint64(int64(v)))
+	}
+	
+	tests := []struct {
+		name    string
+		content string
+		options DatasetOptions
+		format  string
+	}{
+		{"values.txt", "5\n-3\n\n# comment\n 9 \n1\n", DatasetOptions{}, "lines"},
+		{"values.csv", "id,value\na,5\nb,-3\nc,9\nd,1\n", DatasetOptions{Column: "value"}, "csv"},
+		{"values.csv", "10,5\n11,-3\n12,9\n13,1\n", DatasetOptions{Column: "1"}, "csv"},
+		{"values.csv", "value\n5\n-3\n9\n1\n", DatasetOptions{}, "csv"},
+		{"values.i32", string(int32Data), DatasetOptions{}, "int32"},
+		{"values.bin", string(int64Data), DatasetOptions{Format: Int64Format}, "int64"},
+	}
+	
+	for _, test := range tests {
+		dataset, err := ParseDataset(test.name, []byte(test.content), test.options)
+		if err != nil {
+			t.Errorf("ParseDataset(%s, %s) failed: %v", test.name, test.format, err)
+			continue
+		}
+		if !reflect.DeepEqual(dataset.Values, expected) || dataset.Size != len(expected) {
+			t.Errorf("ParseDataset(%s, %s) = %v, expected %v", test.name, test.format, dataset.Values, expected)
+		}
+		if dataset.Format != test.format || dataset.Name != test.name {
+			t.Errorf("Expected %s dataset %s, got %s dataset %s", test.format, test.name, dataset.Format, dataset.Name)
+		}
+		if !strings.HasPrefix(dataset.Checksum, "sha256:") || len(dataset.Checksum) != len("sha256:")+64 {
+			t.Errorf("Unexpected checksum %q", dataset.Checksum)
+		}
+	}
+	
+	first, _ := ParseDataset("a.txt", []byte("1\n2\n"), DatasetOptions{})
+	second, _ := ParseDataset("b.txt", []byte("1\n2\n"), DatasetOptions{})
+	third, _ := ParseDataset("a.txt", []byte("2\n1\n"), DatasetOptions{})
+	if first.Checksum != second.Checksum || first.Checksum == third.Checksum {
+		t.Error("Expected the checksum to depend only on the file contents")
+	}
+	
+	invalid := []struct {
+		content string
+		options DatasetOptions
+	}{
+		{"1\ntwo\n", DatasetOptions{Format: LinesFormat}},
+		{"", DatasetOptions{Format: LinesFormat}},
+		{"a,b\n1,2\n", DatasetOptions{Format: CSVFormat, Column: "c"}},
+		{"1,2\n3\n", DatasetOptions{Format: CSVFormat, Column: "1"}},
+		{"1,2\n3,x\n", DatasetOptions{Format: CSVFormat, Column: "1"}},
+		{"\x01\x02\x03", DatasetOptions{Format: Int32Format}},
+	}
+	for _, test := range invalid {
+		if _, err := ParseDataset("invalid", []byte(test.content), test.options); err == nil {
+			t.Errorf("Expected error for %q (%s)", test.content, GetDatasetFormatName(test.options.Format))
+		}
+	}
+}
+
+func TestLoadDataset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.txt")
+	if err := os.WriteFile(path, []byte("3\n1\n2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	
+	dataset, err := LoadDataset(path, DatasetOptions{})
+	if err != nil {
+		t.Fatalf("LoadDataset failed: %v", err)
+	}
+	if dataset.Name != "snapshot.txt" || !reflect.DeepEqual(dataset.Values, []int{3, 1, 2}) {
+		t.Errorf("Unexpected dataset %s: %v", dataset.Name, dataset.Values)
+	}
+	
+	if _, err := LoadDataset(filepath.Join(t.TempDir(), "missing.txt"), DatasetOptions{}); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestParseQueryDistribution(t *testing.T) {
+	for _, distribution := range GetAllQueryDistributions() {
+		parsed, err := ParseQueryDistribution(GetQueryDistributionName(distribution))
+		if err != nil || parsed != distribution {
+			t.Errorf("ParseQueryDistribution(%s) = %d, %v", GetQueryDistributionName(distribution), parsed, err)
+		}
+	}
+	
+	if _, err := ParseQueryDistribution("gaussian"); err == nil {
+		t.Error("Expected error for unknown query distribution")
+	}
+}
+
+func TestElementConversions(t *testing.T) {
+	keys := []int{5, -3, 5, 12, 0}
+	
+	if got, err := ToInt32s(keys); err != nil || !reflect.DeepEqual(got, []int32{5, -3, 5, 12, 0}) {
+		t.Errorf("ToInt32s = %v, %v", got, err)
+	}
+	for _, key := range []int{math.MaxInt32 + 1, math.MinInt32 - 1} {
+		if _, err := ToInt32s([]int{0, key}); err == nil {
+			t.Errorf("Expected an error converting %d to int32", key)
+		}
+	}
+	if got := ToInt64s(keys); !reflect.DeepEqual(got, []int64{5, -3, 5, 12, 0}) {
+		t.Errorf("ToInt64s = %v", got)
+	}
+	
+	records := ToRecords(keys)
+	for i, record := range records {
+		if record.Key != int64(keys[i]) || record.Payload[0] != int64(i) {
+			t.Errorf("ToRecords[%d] = %+v", i, record)
+		}
+	}
+	
+	floats := ToFloat64s(keys, ArrayParams{}, NewSeededRand(1))
+	if !reflect.DeepEqual(floats, []float64{5, -3, 5, 12, 0}) {
+		t.Errorf("ToFloat64s = %v", floats)
+	}
+}
+
+func TestToFloat64sNaNFraction(t *testing.T) {
+	keys := GenerateArrayWithRand(10000, Random, ArrayParams{}, NewSeededRand(1))
+	floats := ToFloat64s(keys, ArrayParams{NaNFraction: 0.2}, NewSeededRand(2))
+	
+	nans := 0
+	for _, v := range floats {
+		if math.IsNaN(v) {
+			nans++
+		}
+	}
+	if nans < 1600 || nans > 2400 {
+		t.Errorf("Expected about 2000 NaNs with nan_fraction=0.2, got %d", nans)
+	}
+}
+
+func TestToStrings(t *testing.T) {
+	keys := GenerateArrayWithRand(500, FewUnique, ArrayParams{Unique: 20}, NewSeededRand(1))
+	strs := ToStrings(keys, ArrayParams{StringLength: 6, Alphabet: "xyz"}, NewSeededRand(2))
+	
+	for i, s := range strs {
+		if len(s) != 6 || strings.Trim(s, "xyz") != "" {
+			t.Fatalf("String %q does not match length 6 over alphabet xyz", s)
+		}
+		for j := range strs {
+			if cmp.Compare(keys[i], keys[j]) < 0 && strs[i] > strs[j] {
+				t.Fatalf("Order not preserved: %d < %d but %q > %q", keys[i], keys[j], strs[i], strs[j])
+			}
+		}
+	}
+	
+	if got := FormatElementParams(StringElements, 500, ArrayParams{}); got != "string_length=16,alphabet=abcdefghijklmnopqrstuvwxyz" {
+		t.Errorf("FormatElementParams(StringElements) = %q", got)
+	}
+}
+
+func TestVerifySortingElements(t *testing.T) {
+	if !VerifySortingFunc([]string{"b", "a", "c"}, []string{"a", "b", "c"}, strings.Compare) {
+		t.Error("Expected sorted strings to verify")
+	}
+	if VerifySortingFunc([]string{"b", "a", "c"}, []string{"a", "c", "b"}, strings.Compare) {
+		t.Error("Expected unsorted strings to fail verification")
+	}
+	if VerifySortingFunc([]string{"b", "a", "c"}, []string{"a", "a", "c"}, strings.Compare) {
+		t.Error("Expected a changed multiset to fail verification")
+	}
+	
+	original := []float64{3, math.NaN(), 1, 2}
+	if !VerifySortingFloat64(original, []float64{math.NaN(), 1, 2, 3}) {
+		t.Error("Expected NaN-first float64 order to verify")
+	}
+	if VerifySortingFloat64(original, []float64{1, 2, 3, math.NaN()}) {
+		t.Error("Expected NaN-last float64 order to fail verification")
+	}
+	if VerifySortingFloat64(original, []float64{1, 1, 2, 3}) {
+		t.Error("Expected a dropped NaN to fail verification")
+	}
+}
+
+func TestVerifyStable(t *testing.T) {
+	original := ToRecords([]int{2, 1, 2, 1, 3})
+	
+	stable := []algorithms.Record{original[1], original[3], original[0], original[2], original[4]}
+	if !VerifyStable(original, stable) {
+		t.Error("Expected stable order to verify")
+	}
+	
+	unstable := []algorithms.Record{original[3], original[1], original[0], original[2], original[4]}
+	if VerifyStable(original, unstable) {
+		t.Error("Expected swapped equal keys to fail stability verification")
+	}
+	
+	unsorted := []algorithms.Record{original[0], original[1], original[2], original[3], original[4]}
+	if VerifyStable(original, unsorted) {
+		t.Error("Expected unsorted records to fail stability verification")
+	}
+	
+	if VerifyStable(original, stable[:4]) {
+		t.Error("Expected a dropped record to fail stability verification")
+	}
+}
+
+func TestParseElementType(t *testing.T) {
+	for _, elementType := range GetAllElementTypes() {
+		parsed, err := ParseElementType(GetElementTypeName(elementType))
+		if err != nil || parsed != elementType {
+			t.Errorf("ParseElementType(%s) = %d, %v", GetElementTypeName(elementType), parsed, err)
+		}
+	}
+	
+	if _, err := ParseElementType("complex128"); err == nil {
+		t.Error("Expected error for unknown element type")
+	}
+}
@@ -0,0 +1,157 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+type QueryDistribution int
+
+const (
+	UniformQueries QueryDistribution = iota
+	ZipfQueries
+	SequentialQueries
+)
+
+const zipfExponent = 1.1
+
+func GenerateQueries(arr []int, count int, hitRatio float64, distribution QueryDistribution) []int {
+	return GenerateQueriesWithRand(arr, count, hitRatio, distribution, NewRand())
+}
+
+func GenerateQueriesWithRand(arr []int, count int, hitRatio float64, distribution QueryDistribution, rng *rand.Rand) []int {
+	if count <= 0 {
+		return nil
+	}
+	if hitRatio < 0 {
+		hitRatio = 0
+	} else if hitRatio > 1 {
+		hitRatio = 1
+	}
+	
+	keys := distinctSorted(arr)
+	queries := make([]int, count)
+	if len(keys) == 0 {
+		for i := range queries {
+			queries[i] = i
+		}
+		return queries
+	}
+	
+	var zipf *rand.Zipf
+	var hot []int
+	if distribution == ZipfQueries {
+		zipf = rand.NewZipf(rng, zipfExponent, 1, uint64(len(keys)-1))
+		hot = rng.Perm(len(keys))
+	}
+	
+	for i := range queries {
+		var rank int
+		switch distribution {
+		case ZipfQueries:
+			rank = hot[zipf.Uint64()]
+		case SequentialQueries:
+			rank = i % len(keys)
+		default:
+			rank = rng.Intn(len(keys))
+		}
+		
+		if isHit(i, hitRatio) {
+			queries[i] = keys[rank]
+		} else {
+			queries[i] = missNear(keys, rank)
+		}
+	}
+	
+	return queries
+}
+
+func isHit(i int, hitRatio float64) bool {
+	return int(float64(i+1)*hitRatio) > int(float64(i)*hitRatio)
+}
+
+func distinctSorted(arr []int) []int {
+	keys := make([]int, len(arr))
+	copy(keys, arr)
+	sort.Ints(keys)
+	
+	n := 0
+	for i, v := range keys {
+		if i == 0 || v != keys[n-1] {
+			keys[n] = v
+			n++
+		}
+	}
+	return keys[:n]
+}
+
+func missNear(keys []int, rank int) int {
+	v := keys[rank]
+	if rank+1 < len(keys) && keys[rank+1] > v+1 {
+		return v + 1
+	}
+	if rank > 0 && keys[rank-1] < v-1 {
+		return v - 1
+	}
+	if last := keys[len(keys)-1]; last < math.MaxInt-rank {
+		return last + 1 + rank
+	}
+	if first := keys[0]; first > math.MinInt+rank {
+		return first - 1 - rank
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i] > keys[i-1]+1 {
+			return keys[i-1] + 1
+		}
+	}
+	return v
+}
+
+func GetQueryDistributionName(distribution QueryDistribution) string {
+	switch distribution {
+	case UniformQueries:
+		return "uniform"
+	case ZipfQueries:
+		return "zipf"
+	case SequentialQueries:
+		return "sequential"
+	default:
+		return "unknown"
+	}
+}
+
+func GetAllQueryDistributions() []QueryDistribution {
+	return []QueryDistribution{UniformQueries, ZipfQueries, SequentialQueries}
+}
+
+func ParseQueryDistribution(name string) (QueryDistribution, error) {
+	for _, distribution := range GetAllQueryDistributions() {
+		if strings.EqualFold(name, GetQueryDistributionName(distribution)) {
+			return distribution, nil
+		}
+	}
+	return UniformQueries, fmt.Errorf("unknown query distribution: %s", name)
+}
+
+func VerifySearches(arr, queries, results []int) bool {
+	if len(queries) != len(results) {
+		return false
+	}
+	
+	keys := distinctSorted(arr)
+	for i, target := range queries {
+		index := results[i]
+		if index == -1 {
+			j := sort.SearchInts(keys, target)
+			if j < len(keys) && keys[j] == target {
+				return false
+			}
+		} else if index < 0 || index >= len(arr) || arr[index] != target {
+			return false
+		}
+	}
+	return true
+}
@@ -1,9 +1,11 @@
 package export
 
 import (
+	"algorithm-benchmark/algorithms"
 	"algorithm-benchmark/benchmark"
 	"encoding/csv"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -23,13 +25,54 @@ func ExportToCSV(results []benchmark.BenchmarkResult, filename string) error {
 	header := []string{
 		"Algorithm",
 		"Array Type",
+		"Element Type",
+		"Array Params",
+		"Dataset",
+		"Dataset Checksum",
+		"Plan",
+		"Plan Checksum",
 		"Size",
+		"Seed",
+		"Pivot Strategy",
+		"Partition Scheme",
 		"Mean Duration (ns)",
 		"Std Deviation (ns)",
 		"Min Duration (ns)",
 		"Max Duration (ns)",
+		"Median Duration (ns)",
+		"P90 Duration (ns)",
+		"P95 Duration (ns)",
+		"P99 Duration (ns)",
+		"MAD (ns)",
+		"Coefficient of Variation",
+		"CI Lower (ns)",
+		"CI Upper (ns)",
+		"Layout Duration (ns)",
+		"Queries",
+		"Hit Ratio",
+		"Query Distribution",
+		"Query Latency (ns)",
+		"Throughput (queries/s)",
+		"Comparisons",
+		"Swaps",
+		"Reads",
+		"Writes",
+		"Stable",
 		"Memory Used (bytes)",
+		"Bytes Allocated",
+		"Allocations",
+		"Bytes per Op",
+		"Allocs per Op",
+		"Peak Heap (bytes)",
+		"Peak Heap Mode",
 		"Runs",
+		"Invocations per Run",
+		"Precision (95% CI)",
+		"Target Precision",
+		"Warm-up Iterations",
+		"Reuse Input",
+		"Status",
+		"Status Detail",
 	}
 	
 	if err := writer.Write(header); err != nil {
@@ -40,14 +83,54 @@ func ExportToCSV(results []benchmark.BenchmarkResult, filename string) error {
 		record := []string{
 			result.Algorithm,
 			result.ArrayType,
+			result.ElementType,
+			result.ArrayParams,
+			result.Dataset,
+			result.Checksum,
+			result.Plan,
+			result.PlanChecksum,
 			strconv.Itoa(result.Size),
+			strconv.FormatInt(result.Seed, 10),
+			result.PivotStrategy,
+			result.PartitionScheme,
 			strconv.FormatInt(result.MeanDuration.Nanoseconds(), 10),
 			strconv.FormatInt(result.StdDeviation.Nanoseconds(), 10),
 			strconv.FormatInt(result.MinDuration.Nanoseconds(), 10),
 			strconv.FormatInt(result.MaxDuration.Nanoseconds(), 10),
+			strconv.FormatInt(result.MedianDuration.Nanoseconds(), 10),
+			strconv.FormatInt(result.P90Duration.Nanoseconds(), 10),
+			strconv.FormatInt(result.P95Duration.Nanoseconds(), 10),
+			strconv.FormatInt(result.P99Duration.Nanoseconds(), 10),
+			strconv.FormatInt(result.MAD.Nanoseconds(), 10),
+			strconv.FormatFloat(result.CV, 'f', 6, 64),
+			strconv.FormatInt(result.CILower.Nanoseconds(), 10),
+			strconv.FormatInt(result.CIUpper.Nanoseconds(), 10),
+			strconv.FormatInt(result.LayoutDuration.Nanoseconds(), 10),
+			strconv.Itoa(result.Queries),
+			strconv.FormatFloat(result.HitRatio, 'f', -1, 64),
+			result.QueryDistribution,
+			strconv.FormatInt(result.QueryLatency.Nanoseconds(), 10),
+			strconv.FormatFloat(result.Throughput, 'f', 0, 64),
+		}
+		record = append(record, formatOperationCounts(result)...)
+		record = append(record,
+			formatStable(result.Stable, ""),
 			strconv.FormatUint(result.MemoryUsed, 10),
+			strconv.FormatUint(result.BytesAllocated, 10),
+			strconv.FormatUint(result.Allocations, 10),
+			strconv.FormatUint(result.BytesPerOp, 10),
+			strconv.FormatUint(result.AllocsPerOp, 10),
+			strconv.FormatUint(result.PeakHeap, 10),
+			result.PeakHeapMode,
 			strconv.Itoa(result.Runs),
-		}
+			strconv.Itoa(result.Batch),
+			formatPrecision(result),
+			strconv.FormatFloat(result.TargetPrecision, 'f', -1, 64),
+			strconv.Itoa(result.Warmup),
+			strconv.FormatBool(result.ReuseInput),
+			result.Status,
+			result.Detail,
+		)
 		
 		if err := writer.Write(record); err != nil {
 			return err
@@ -58,57 +141,203 @@ func ExportToCSV(results []benchmark.BenchmarkResult, filename string) error {
 }
 
 func ExportToMarkdown(results []benchmark.BenchmarkResult, filename string) error {
+	return ExportToMarkdownWithPlan(results, nil, filename)
+}
+
+func ExportToMarkdownWithPlan(results []benchmark.BenchmarkResult, plan *benchmark.Plan, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 	
-	content := generateMarkdownContent(results)
+	content := generateMarkdownContent(results, plan)
 	_, err = file.WriteString(content)
 	return err
 }
 
-func generateMarkdownContent(results []benchmark.BenchmarkResult) string {
+func generateMarkdownContent(results []benchmark.BenchmarkResult, plan *benchmark.Plan) string {
 	var sb strings.Builder
 	
 	sb.WriteString("# Algorithm Benchmark Results\n\n")
 	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
 	
+	if plan != nil {
+		sb.WriteString("## Plan\n\n")
+		sb.WriteString(fmt.Sprintf("- Name: %s\n", plan.Name))
+		sb.WriteString(fmt.Sprintf("- Checksum: `%s`\n", plan.Checksum))
+		sb.WriteString(fmt.Sprintf("- Benchmarks: %d\n\n", plan.Cells()))
+		sb.WriteString("```" + plan.Format + "\n" + strings.TrimSpace(plan.Source) + "\n```\n\n")
+	}
+	
 	sb.WriteString("## Summary\n\n")
-	sb.WriteString("| Algorithm | Array Type | Size | Mean Duration | Std Deviation | Memory Used | Runs |\n")
-	sb.WriteString("|-----------|------------|------|---------------|---------------|-------------|------|\n")
+	sb.WriteString("| Algorithm | Variant | Array Type | Elements | Size | Seed | Mean Duration | Std Deviation | Memory Used | Runs | 95% CI | Status |\n")
+	sb.WriteString("|-----------|---------|------------|----------|------|------|---------------|---------------|-------------|------|--------|--------|\n")
 	
 	for _, result := range results {
-		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %s | %s | %s | %d |\n",
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d | %d | %s | %s | %s | %s | %s | %s |\n",
 			result.Algorithm,
-			result.ArrayType,
+			formatVariant(result),
+			formatArrayType(result),
+			formatElementType(result),
 			result.Size,
+			result.Seed,
 			formatDuration(result.MeanDuration),
 			formatDuration(result.StdDeviation),
 			formatBytes(result.MemoryUsed),
-			result.Runs,
+			formatRuns(result),
+			formatConfidence(result),
+			result.Status,
 		))
 	}
 	
+	sb.WriteString("\n## Statistics\n\n")
+	sb.WriteString("Percentiles are interpolated between runs. MAD is the median absolute deviation from the median, CV is the standard deviation divided by the mean, and the confidence interval of the mean is a 95% bootstrap interval.\n\n")
+	sb.WriteString("| Algorithm | Variant | Array Type | Size | Median | P90 | P95 | P99 | MAD | CV | 95% CI of Mean |\n")
+	sb.WriteString("|-----------|---------|------------|------|--------|-----|-----|-----|-----|----|----------------|\n")
+	
+	for _, result := range results {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s | %s | %s | %s | %s | %.1f%% | %s – %s |\n",
+			result.Algorithm,
+			formatVariant(result),
+			formatArrayType(result),
+			result.Size,
+			formatDuration(result.MedianDuration),
+			formatDuration(result.P90Duration),
+			formatDuration(result.P95Duration),
+			formatDuration(result.P99Duration),
+			formatDuration(result.MAD),
+			result.CV*100,
+			formatDuration(result.CILower),
+			formatDuration(result.CIUpper),
+		))
+	}
+	
+	summary := benchmark.Summarize(results)
+	if len(summary.Failures) > 0 {
+		sb.WriteString("\n## Failures\n\n")
+		sb.WriteString(fmt.Sprintf("%s.\n\n", summary))
+		sb.WriteString("| Algorithm | Variant | Array Type | Size | Seed | Status | Detail |\n")
+		sb.WriteString("|-----------|---------|------------|------|------|--------|--------|\n")
+		
+		for _, result := range summary.Failures {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %s | %s |\n",
+				result.Algorithm,
+				formatVariant(result),
+				formatArrayType(result),
+				result.Size,
+				result.Seed,
+				result.Status,
+				strings.ReplaceAll(result.Detail, "|", "\\|"),
+			))
+		}
+		
+		for _, result := range summary.Failures {
+			if result.Stack == "" {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n### Stack trace: %s, %s, size %d, seed %d\n\n", result.Algorithm, formatArrayType(result), result.Size, result.Seed))
+			sb.WriteString("```\n" + strings.TrimSpace(result.Stack) + "\n```\n")
+		}
+	}
+	
+	datasets := getUniqueDatasets(results)
+	if len(datasets) > 0 {
+		sb.WriteString("\n## Datasets\n\n")
+		sb.WriteString("| Dataset | Size | Checksum |\n")
+		sb.WriteString("|---------|------|----------|\n")
+		
+		for _, result := range datasets {
+			sb.WriteString(fmt.Sprintf("| %s | %d | `%s` |\n",
+				result.Dataset,
+				result.Size,
+				result.Checksum,
+			))
+		}
+	}
+	
+	searchResults := filterSearchWorkloads(results)
+	if len(searchResults) > 0 {
+		sb.WriteString("\n## Search Workloads\n\n")
+		sb.WriteString("| Algorithm | Array Type | Size | Queries | Distribution | Hit Ratio | Query Latency | Throughput |\n")
+		sb.WriteString("|-----------|------------|------|---------|--------------|-----------|---------------|------------|\n")
+		
+		for _, result := range searchResults {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s | %.0f%% | %s | %.0f queries/s |\n",
+				result.Algorithm,
+				formatArrayType(result),
+				result.Size,
+				result.Queries,
+				formatQueryDistribution(result),
+				result.HitRatio*100,
+				formatDuration(result.QueryLatency),
+				result.Throughput,
+			))
+		}
+	}
+	
+	countedResults := filterOperationCounts(results)
+	if len(countedResults) > 0 {
+		sb.WriteString("\n## Operation Counts\n\n")
+		sb.WriteString("Counts are averaged per run. For sorts and selections, the last two columns divide the comparison count by n log2 n and n^2.\n\n")
+		sb.WriteString("| Algorithm | Variant | Array Type | Size | Comparisons | Swaps | Reads | Writes | Cmp / n log n | Cmp / n^2 |\n")
+		sb.WriteString("|-----------|---------|------------|------|-------------|-------|-------|--------|---------------|-----------|\n")
+		
+		for _, result := range countedResults {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %d | %d | %d | %s | %s |\n",
+				result.Algorithm,
+				formatVariant(result),
+				formatArrayType(result),
+				result.Size,
+				result.Operations.Comparisons,
+				result.Operations.Swaps,
+				result.Operations.Reads,
+				result.Operations.Writes,
+				formatBoundRatio(result, float64(result.Size)*math.Log2(float64(result.Size))),
+				formatBoundRatio(result, float64(result.Size)*float64(result.Size)),
+			))
+		}
+	}
+	
+	stabilityResults := getUniqueStability(results)
+	if len(stabilityResults) > 0 {
+		sb.WriteString("\n## Stability\n\n")
+		sb.WriteString("Verified by sorting key/payload records with many duplicate keys and checking that equal keys keep their input order.\n\n")
+		sb.WriteString("| Algorithm | Variant | Declared | Verified |\n")
+		sb.WriteString("|-----------|---------|----------|----------|\n")
+		
+		for _, result := range stabilityResults {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				result.Algorithm,
+				formatVariant(result),
+				formatDeclaredStability(result.Algorithm),
+				formatStable(result.Stable, "-"),
+			))
+		}
+	}
+	
 	sb.WriteString("\n## Detailed Results\n\n")
 	
 	algorithms := getUniqueAlgorithms(results)
 	for _, algorithm := range algorithms {
 		sb.WriteString(fmt.Sprintf("### %s\n\n", algorithm))
-		sb.WriteString("| Array Type | Size | Mean | Std Dev | Min | Max | Memory |\n")
-		sb.WriteString("|------------|------|------|---------|-----|-----|--------|\n")
+		sb.WriteString("| Variant | Array Type | Size | Mean | Std Dev | Min | Max | Layout | Memory | Allocs/op | Peak Heap |\n")
+		sb.WriteString("|---------|------------|------|------|---------|-----|-----|--------|--------|-----------|-----------|\n")
 		
 		algorithmResults := filterByAlgorithm(results, algorithm)
 		for _, result := range algorithmResults {
-			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s | %s |\n",
-				result.ArrayType,
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %s | %s | %s | %s | %s | %s/op | %d | %s |\n",
+				formatVariant(result),
+				formatArrayType(result),
 				result.Size,
 				formatDuration(result.MeanDuration),
 				formatDuration(result.StdDeviation),
 				formatDuration(result.MinDuration),
 				formatDuration(result.MaxDuration),
-				formatBytes(result.MemoryUsed),
+				formatLayoutDuration(result),
+				formatBytes(result.BytesPerOp),
+				result.AllocsPerOp,
+				formatPeakHeap(result),
 			))
 		}
 		sb.WriteString("\n")
@@ -142,6 +371,154 @@ func formatBytes(bytes uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+func formatArrayType(result benchmark.BenchmarkResult) string {
+	if result.Dataset != "" {
+		return fmt.Sprintf("%s (%s)", result.ArrayType, result.Dataset)
+	}
+	if result.ArrayParams == "" {
+		return result.ArrayType
+	}
+	return fmt.Sprintf("%s (%s)", result.ArrayType, result.ArrayParams)
+}
+
+func formatElementType(result benchmark.BenchmarkResult) string {
+	if result.ElementType == "" {
+		return "int"
+	}
+	return result.ElementType
+}
+
+func formatStable(stable *bool, unknown string) string {
+	if stable == nil {
+		return unknown
+	}
+	if *stable {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatDeclaredStability(name string) string {
+	algorithm, ok := algorithms.Get(name)
+	if !ok {
+		return "-"
+	}
+	return formatStable(&algorithm.Metadata.Stable, "-")
+}
+
+func formatPrecision(result benchmark.BenchmarkResult) string {
+	if result.Runs < 2 {
+		return ""
+	}
+	return strconv.FormatFloat(result.Precision, 'f', 6, 64)
+}
+
+func formatConfidence(result benchmark.BenchmarkResult) string {
+	if result.Runs < 2 {
+		return "-"
+	}
+	return fmt.Sprintf("±%.1f%%", result.Precision*100)
+}
+
+func formatRuns(result benchmark.BenchmarkResult) string {
+	if result.Batch > 1 {
+		return fmt.Sprintf("%d × %d", result.Runs, result.Batch)
+	}
+	return strconv.Itoa(result.Runs)
+}
+
+func formatVariant(result benchmark.BenchmarkResult) string {
+	if result.PivotStrategy == "" && result.PartitionScheme == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s / %s", result.PivotStrategy, result.PartitionScheme)
+}
+
+func formatPeakHeap(result benchmark.BenchmarkResult) string {
+	if result.PeakHeapMode != benchmark.PeakHeapUntimed {
+		return "-"
+	}
+	return formatBytes(result.PeakHeap)
+}
+
+func formatLayoutDuration(result benchmark.BenchmarkResult) string {
+	if result.LayoutDuration == 0 {
+		return "-"
+	}
+	return formatDuration(result.LayoutDuration)
+}
+
+func formatQueryDistribution(result benchmark.BenchmarkResult) string {
+	if result.QueryDistribution == "" {
+		return "-"
+	}
+	return result.QueryDistribution
+}
+
+func formatOperationCounts(result benchmark.BenchmarkResult) []string {
+	if result.Operations == nil {
+		return []string{"", "", "", ""}
+	}
+	return []string{
+		strconv.FormatUint(result.Operations.Comparisons, 10),
+		strconv.FormatUint(result.Operations.Swaps, 10),
+		strconv.FormatUint(result.Operations.Reads, 10),
+		strconv.FormatUint(result.Operations.Writes, 10),
+	}
+}
+
+func formatBoundRatio(result benchmark.BenchmarkResult, bound float64) string {
+	if result.Queries > 0 || bound <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", float64(result.Operations.Comparisons)/bound)
+}
+
+func filterOperationCounts(results []benchmark.BenchmarkResult) []benchmark.BenchmarkResult {
+	var filtered []benchmark.BenchmarkResult
+	for _, result := range results {
+		if result.Operations != nil {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+func filterSearchWorkloads(results []benchmark.BenchmarkResult) []benchmark.BenchmarkResult {
+	var filtered []benchmark.BenchmarkResult
+	for _, result := range results {
+		if result.Queries > 0 {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+func getUniqueDatasets(results []benchmark.BenchmarkResult) []benchmark.BenchmarkResult {
+	seen := make(map[string]bool)
+	var unique []benchmark.BenchmarkResult
+	for _, result := range results {
+		if result.Dataset != "" && !seen[result.Checksum] {
+			seen[result.Checksum] = true
+			unique = append(unique, result)
+		}
+	}
+	return unique
+}
+
+func getUniqueStability(results []benchmark.BenchmarkResult) []benchmark.BenchmarkResult {
+	seen := make(map[string]bool)
+	var unique []benchmark.BenchmarkResult
+	for _, result := range results {
+		key := result.Algorithm + "/" + formatVariant(result)
+		if result.Stable != nil && !seen[key] {
+			seen[key] = true
+			unique = append(unique, result)
+		}
+	}
+	return unique
+}
+
 func getUniqueAlgorithms(results []benchmark.BenchmarkResult) []string {
 	algorithms := make(map[string]bool)
 	for _, result := range results {
@@ -1,40 +1,111 @@
 package web
 
 import (
+	"algorithm-benchmark/algorithms"
 	"algorithm-benchmark/benchmark"
 	"algorithm-benchmark/data"
 	"algorithm-benchmark/export"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 )
 
 type WebServer struct {
 	benchmarkSuite *benchmark.BenchmarkSuite
 	templates      *template.Template
+	
+	datasetsMu   sync.Mutex
+	datasets     map[string]*data.Dataset
+	datasetBytes int
 }
 
 type BenchmarkRequest struct {
-	Algorithm string `json:"algorithm"`
-	ArrayType string `json:"arrayType"`
-	Size      int    `json:"size"`
-	Runs      int    `json:"runs"`
+	Algorithm         string   `json:"algorithm"`
+	ArrayType         string   `json:"arrayType"`
+	ArrayParams       string   `json:"arrayParams"`
+	Size              int      `json:"size"`
+	Runs              int      `json:"runs"`
+	Warmup            int      `json:"warmup"`
+	ReuseInput        bool     `json:"reuseInput"`
+	Precision         float64  `json:"precision"`
+	MinRuns           int      `json:"minRuns"`
+	MaxRuns           int      `json:"maxRuns"`
+	TimeBudget        string   `json:"timeBudget"`
+	Timeout           string   `json:"timeout"`
+	Pivot             string   `json:"pivot"`
+	Partition         string   `json:"partition"`
+	Queries           int      `json:"queries"`
+	HitRatio          *float64 `json:"hitRatio"`
+	QueryDistribution string   `json:"queryDistribution"`
+	Seed              int64    `json:"seed"`
+	Dataset           string   `json:"dataset"`
+	ElementType       string   `json:"elementType"`
+	CountOperations   bool     `json:"countOperations"`
+	PeakHeap          bool     `json:"peakHeap"`
 }
 
 type BenchmarkResponse struct {
 	Success bool                     `json:"success"`
 	Message string                   `json:"message,omitempty"`
 	Results []benchmark.BenchmarkResult `json:"results,omitempty"`
+	Plan    *benchmark.Plan          `json:"plan,omitempty"`
+}
+
+type TraceRequest struct {
+	Algorithm   string `json:"algorithm"`
+	ArrayType   string `json:"arrayType"`
+	ArrayParams string `json:"arrayParams"`
+	Size        int    `json:"size"`
+	Pivot       string `json:"pivot"`
+	Partition   string `json:"partition"`
+	Seed        int64  `json:"seed"`
+}
+
+type TraceResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message,omitempty"`
+	Trace   *algorithms.Trace `json:"trace,omitempty"`
+	Seed    int64             `json:"seed,omitempty"`
 }
 
+type DatasetResponse struct {
+	Success  bool            `json:"success"`
+	Message  string          `json:"message,omitempty"`
+	Dataset  *data.Dataset   `json:"dataset,omitempty"`
+	Datasets []*data.Dataset `json:"datasets,omitempty"`
+}
+
+const maxTraceSize = 256
+
+const maxDatasetUpload = 64 << 20
+
+const (
+	maxDatasets     = 16
+	maxDatasetBytes = 512 << 20
+)
+
+var (
+	errDatasetExists = errors.New("a different dataset with this name is already loaded")
+	errDatasetLimit  = errors.New("dataset limit reached, delete a dataset first")
+)
+
+const maxPlanUpload = 1 << 20
+
 func NewWebServer() *WebServer {
 	templates := template.Must(template.ParseGlob("web/templates/*.html"))
 	
 	return &WebServer{
 		benchmarkSuite: benchmark.NewBenchmarkSuite(),
 		templates:      templates,
+		datasets:       make(map[string]*data.Dataset),
 	}
 }
 
@@ -42,6 +113,9 @@ func (ws *WebServer) Start(port string) {
 	http.HandleFunc("/", ws.handleIndex)
 	http.HandleFunc("/api/benchmark", ws.handleBenchmark)
 	http.HandleFunc("/api/benchmark/all", ws.handleBenchmarkAll)
+	http.HandleFunc("/api/trace", ws.handleTrace)
+	http.HandleFunc("/api/dataset", ws.handleDataset)
+	http.HandleFunc("/api/plan", ws.handlePlan)
 	http.HandleFunc("/api/export/csv", ws.handleExportCSV)
 	http.HandleFunc("/api/export/md", ws.handleExportMarkdown)
 	http.HandleFunc("/api/results", ws.handleGetResults)
@@ -58,7 +132,52 @@ func (ws *WebServer) Start(port string) {
 }
 
 func (ws *WebServer) handleIndex(w http.ResponseWriter, r *http.Request) {
-	if err := ws.templates.ExecuteTemplate(w, "index.html", nil); err != nil {
+	type arrayTypeOption struct {
+		Key  string
+		Name string
+	}
+	
+	pageData := struct {
+		Algorithms         []algorithms.Algorithm
+		SortAlgorithms     []algorithms.Algorithm
+		ArrayTypes         []arrayTypeOption
+		PivotStrategies    []string
+		PartitionSchemes   []string
+		QueryDistributions []string
+		DatasetFormats     []string
+		ElementTypes       []string
+		MaxTraceSize       int
+		DefaultWarmup      int
+	}{
+		Algorithms:     algorithms.All(),
+		SortAlgorithms: algorithms.ByKind(algorithms.Sort),
+		MaxTraceSize:   maxTraceSize,
+		DefaultWarmup:  benchmark.DefaultWarmup,
+	}
+	
+	for _, arrayType := range append(data.GetAllArrayTypes(), data.GetAdversarialArrayTypes()...) {
+		pageData.ArrayTypes = append(pageData.ArrayTypes, arrayTypeOption{
+			Key:  data.GetArrayTypeKey(arrayType),
+			Name: data.GetArrayTypeName(arrayType),
+		})
+	}
+	for _, elementType := range data.GetAllElementTypes() {
+		pageData.ElementTypes = append(pageData.ElementTypes, data.GetElementTypeName(elementType))
+	}
+	for _, format := range data.GetAllDatasetFormats() {
+		pageData.DatasetFormats = append(pageData.DatasetFormats, data.GetDatasetFormatName(format))
+	}
+	for _, pivot := range algorithms.GetAllPivotStrategies() {
+		pageData.PivotStrategies = append(pageData.PivotStrategies, algorithms.GetPivotStrategyName(pivot))
+	}
+	for _, scheme := range algorithms.GetAllPartitionSchemes() {
+		pageData.PartitionSchemes = append(pageData.PartitionSchemes, algorithms.GetPartitionSchemeName(scheme))
+	}
+	for _, distribution := range data.GetAllQueryDistributions() {
+		pageData.QueryDistributions = append(pageData.QueryDistributions, data.GetQueryDistributionName(distribution))
+	}
+	
+	if err := ws.templates.ExecuteTemplate(w, "index.html", pageData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
@@ -78,17 +197,98 @@ func (ws *WebServer) handleBenchmark(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	arrayType := ws.parseArrayType(req.ArrayType)
+	arrayType, arrayParams, err := ws.parseArray(req.ArrayType, req.ArrayParams)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	options, err := ws.parseSortOptions(req.Pivot, req.Partition)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	workload, err := ws.parseWorkload(req.Queries, req.HitRatio, req.QueryDistribution)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	adaptive, err := ws.parseAdaptiveRuns(req.Precision, req.MinRuns, req.MaxRuns, req.TimeBudget)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	timeout, err := ws.parseTimeout("timeout", req.Timeout)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	elementType := data.IntElements
+	if req.ElementType != "" {
+		elementType, err = data.ParseElementType(req.ElementType)
+		if err != nil {
+			ws.sendJSONResponse(w, BenchmarkResponse{
+				Success: false,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+	}
 	
 	config := benchmark.BenchmarkConfig{
-		Algorithm: req.Algorithm,
-		ArrayType: arrayType,
-		Size:      req.Size,
-		Runs:      req.Runs,
-		Target:    req.Size / 2,
+		Algorithm:   req.Algorithm,
+		ArrayType:   arrayType,
+		ArrayParams: arrayParams,
+		Size:        req.Size,
+		Runs:        req.Runs,
+		Warmup:      req.Warmup,
+		ReuseInput:  req.ReuseInput,
+		Adaptive:    adaptive,
+		Target:      req.Size / 2,
+		Pivot:       options.Pivot,
+		Partition:   options.Partition,
+		Workload:    workload,
+		Seed:        req.Seed,
+		ElementType: elementType,
+		Timeout:     timeout,
+		
+		CountOperations: req.CountOperations,
+		MeasurePeakHeap: req.PeakHeap,
+	}
+	
+	if req.Dataset != "" {
+		dataset, ok := ws.getDataset(req.Dataset)
+		if !ok {
+			ws.sendJSONResponse(w, BenchmarkResponse{
+				Success: false,
+				Message: fmt.Sprintf("unknown dataset: %s", req.Dataset),
+			}, http.StatusBadRequest)
+			return
+		}
+		config.Dataset = dataset
+		config.Target = dataset.Size / 2
 	}
 	
-	result, err := ws.benchmarkSuite.RunBenchmark(config)
+	result, err := ws.benchmarkSuite.RunBenchmarkContext(r.Context(), config)
 	if err != nil {
 		ws.sendJSONResponse(w, BenchmarkResponse{
 			Success: false,
@@ -110,7 +310,24 @@ func (ws *WebServer) handleBenchmarkAll(w http.ResponseWriter, r *http.Request)
 	}
 	
 	var req struct {
-		Runs int `json:"runs"`
+		Runs              int      `json:"runs"`
+		Warmup            int      `json:"warmup"`
+		ReuseInput        bool     `json:"reuseInput"`
+		Precision         float64  `json:"precision"`
+		MinRuns           int      `json:"minRuns"`
+		MaxRuns           int      `json:"maxRuns"`
+		TimeBudget        string   `json:"timeBudget"`
+		Timeout           string   `json:"timeout"`
+		SuiteTimeout      string   `json:"suiteTimeout"`
+		CellBudget        string   `json:"cellBudget"`
+		SizeCaps          string   `json:"sizeCaps"`
+		ArrayParams       string   `json:"arrayParams"`
+		Queries           int      `json:"queries"`
+		HitRatio          *float64 `json:"hitRatio"`
+		QueryDistribution string   `json:"queryDistribution"`
+		Seed              int64    `json:"seed"`
+		CountOperations   bool     `json:"countOperations"`
+		PeakHeap          bool     `json:"peakHeap"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -121,11 +338,99 @@ func (ws *WebServer) handleBenchmarkAll(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
+	workload, err := ws.parseWorkload(req.Queries, req.HitRatio, req.QueryDistribution)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	arrayParams, err := data.ParseArrayParams(req.ArrayParams)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	adaptive, err := ws.parseAdaptiveRuns(req.Precision, req.MinRuns, req.MaxRuns, req.TimeBudget)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	timeout, err := ws.parseTimeout("timeout", req.Timeout)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	suiteTimeout, err := ws.parseTimeout("suite timeout", req.SuiteTimeout)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	cellBudget := benchmark.DefaultCellBudget
+	if req.CellBudget != "" {
+		if cellBudget, err = ws.parseTimeout("cell budget", req.CellBudget); err != nil {
+			ws.sendJSONResponse(w, BenchmarkResponse{
+				Success: false,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+	}
+	
+	sizeCaps, err := benchmark.ParseSizeCaps(req.SizeCaps)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	ctx := r.Context()
+	if suiteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, suiteTimeout)
+		defer cancel()
+	}
+	
 	ws.benchmarkSuite.ClearResults()
 	
-	sizes := []int{1000, 10000, 100000, 1000000}
+	base := benchmark.BenchmarkConfig{
+		ArrayParams: arrayParams,
+		Runs:        req.Runs,
+		Warmup:      req.Warmup,
+		ReuseInput:  req.ReuseInput,
+		Adaptive:    adaptive,
+		Workload:    workload,
+		Seed:        req.Seed,
+		Timeout:     timeout,
+		CellBudget:  cellBudget,
+		SizeCaps:    sizeCaps,
+		
+		CountOperations: req.CountOperations,
+		MeasurePeakHeap: req.PeakHeap,
+	}
+	
+	sizes := benchmark.DefaultSizes()
 	
-	if err := ws.benchmarkSuite.RunSearchBenchmarks(sizes, req.Runs); err != nil {
+	if err := ws.benchmarkSuite.RunSearchBenchmarks(ctx, sizes, base); err != nil && ctx.Err() == nil {
 		ws.sendJSONResponse(w, BenchmarkResponse{
 			Success: false,
 			Message: fmt.Sprintf("Search benchmarks failed: %v", err),
@@ -133,7 +438,7 @@ func (ws *WebServer) handleBenchmarkAll(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
-	if err := ws.benchmarkSuite.RunSortBenchmarks(sizes, req.Runs); err != nil {
+	if err := ws.benchmarkSuite.RunSortBenchmarks(ctx, sizes, base); err != nil && ctx.Err() == nil {
 		ws.sendJSONResponse(w, BenchmarkResponse{
 			Success: false,
 			Message: fmt.Sprintf("Sort benchmarks failed: %v", err),
@@ -141,12 +446,307 @@ func (ws *WebServer) handleBenchmarkAll(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
+	if err := ws.benchmarkSuite.RunSelectBenchmarks(ctx, sizes, base); err != nil && ctx.Err() == nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: fmt.Sprintf("Select benchmarks failed: %v", err),
+		}, http.StatusInternalServerError)
+		return
+	}
+	
+	var message string
+	if summary := benchmark.Summarize(ws.benchmarkSuite.GetResults()); len(summary.Failures) > 0 {
+		message = fmt.Sprintf("Completed with failures: %s", summary)
+	}
+	if err := ctx.Err(); err != nil {
+		message = fmt.Sprintf("Suite stopped early (%v): %s", err, benchmark.Summarize(ws.benchmarkSuite.GetResults()))
+	}
+	
+	ws.sendJSONResponse(w, BenchmarkResponse{
+		Success: true,
+		Message: message,
+		Results: ws.benchmarkSuite.GetResults(),
+	}, http.StatusOK)
+}
+
+func (ws *WebServer) handleTrace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	
+	var req TraceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: "Invalid JSON request",
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	if req.Size < 1 || req.Size > maxTraceSize {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: fmt.Sprintf("trace size must be between 1 and %d", maxTraceSize),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	options, err := ws.parseSortOptions(req.Pivot, req.Partition)
+	if err != nil {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	arrayType, arrayParams, err := ws.parseArray(req.ArrayType, req.ArrayParams)
+	if err != nil {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	seed := req.Seed
+	if seed == 0 {
+		seed = benchmark.NewSeed()
+	}
+	rng := data.NewSeededRand(seed)
+	options.Rand = data.NewSeededRand(rng.Int63())
+	
+	arr, err := benchmark.GenerateInput(req.Algorithm, req.Size, arrayType, arrayParams, options, rng)
+	if err != nil {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	trace, err := algorithms.TraceSort(req.Algorithm, arr, options, algorithms.DefaultTraceLimit)
+	if err != nil {
+		ws.sendJSONResponse(w, TraceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	ws.sendJSONResponse(w, TraceResponse{
+		Success: true,
+		Trace:   trace,
+		Seed:    seed,
+	}, http.StatusOK)
+}
+
+func (ws *WebServer) handleDataset(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success:  true,
+			Datasets: ws.listDatasets(),
+		}, http.StatusOK)
+		return
+	case http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		if !ws.deleteDataset(name) {
+			ws.sendJSONResponse(w, DatasetResponse{
+				Success: false,
+				Message: fmt.Sprintf("Unknown dataset: %s", name),
+			}, http.StatusNotFound)
+			return
+		}
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success:  true,
+			Message:  fmt.Sprintf("Deleted %s", name),
+			Datasets: ws.listDatasets(),
+		}, http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	
+	r.Body = http.MaxBytesReader(w, r.Body, maxDatasetUpload)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid upload: %v", err),
+		}, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	
+	format := data.AutoFormat
+	if value := r.FormValue("format"); value != "" {
+		format, err = data.ParseDatasetFormat(value)
+		if err != nil {
+			ws.sendJSONResponse(w, DatasetResponse{
+				Success: false,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+	}
+	
+	content, err := io.ReadAll(file)
+	if err != nil {
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid upload: %v", err),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	dataset, err := data.ParseDataset(header.Filename, content, data.DatasetOptions{
+		Format: format,
+		Column: r.FormValue("column"),
+	})
+	if err != nil {
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	if err := ws.addDataset(dataset); err != nil {
+		status := http.StatusConflict
+		if errors.Is(err, errDatasetLimit) {
+			status = http.StatusInsufficientStorage
+		}
+		ws.sendJSONResponse(w, DatasetResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s: %v", dataset.Name, err),
+		}, status)
+		return
+	}
+	
+	ws.sendJSONResponse(w, DatasetResponse{
+		Success:  true,
+		Dataset:  dataset,
+		Datasets: ws.listDatasets(),
+	}, http.StatusOK)
+}
+
+func (ws *WebServer) handlePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	
+	r.Body = http.MaxBytesReader(w, r.Body, maxPlanUpload)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid upload: %v", err),
+		}, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	
+	content, err := io.ReadAll(file)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: fmt.Sprintf("Invalid upload: %v", err),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	plan, err := benchmark.ParsePlan(header.Filename, content)
+	if err != nil {
+		ws.sendJSONResponse(w, BenchmarkResponse{
+			Success: false,
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	
+	ws.benchmarkSuite.ClearResults()
+	
+	var message string
+	err = ws.benchmarkSuite.RunPlan(r.Context(), plan)
+	if summary := benchmark.Summarize(ws.benchmarkSuite.GetResults()); len(summary.Failures) > 0 {
+		message = fmt.Sprintf("Completed with failures: %s", summary)
+	}
+	if err != nil {
+		message = fmt.Sprintf("Plan stopped early (%v): %s", err, benchmark.Summarize(ws.benchmarkSuite.GetResults()))
+	}
+	
 	ws.sendJSONResponse(w, BenchmarkResponse{
 		Success: true,
+		Message: message,
 		Results: ws.benchmarkSuite.GetResults(),
+		Plan:    plan,
 	}, http.StatusOK)
 }
 
+func (ws *WebServer) addDataset(dataset *data.Dataset) error {
+	ws.datasetsMu.Lock()
+	defer ws.datasetsMu.Unlock()
+	
+	if existing, ok := ws.datasets[dataset.Name]; ok {
+		if existing.Checksum != dataset.Checksum {
+			return errDatasetExists
+		}
+		return nil
+	}
+	
+	size := datasetBytes(dataset)
+	if len(ws.datasets) >= maxDatasets || ws.datasetBytes+size > maxDatasetBytes {
+		return errDatasetLimit
+	}
+	ws.datasets[dataset.Name] = dataset
+	ws.datasetBytes += size
+	return nil
+}
+
+func (ws *WebServer) deleteDataset(name string) bool {
+	ws.datasetsMu.Lock()
+	defer ws.datasetsMu.Unlock()
+	
+	dataset, ok := ws.datasets[name]
+	if !ok {
+		return false
+	}
+	delete(ws.datasets, name)
+	ws.datasetBytes -= datasetBytes(dataset)
+	return true
+}
+
+func datasetBytes(dataset *data.Dataset) int {
+	return len(dataset.Values) * strconv.IntSize / 8
+}
+
+func (ws *WebServer) getDataset(name string) (*data.Dataset, bool) {
+	ws.datasetsMu.Lock()
+	defer ws.datasetsMu.Unlock()
+	
+	dataset, ok := ws.datasets[name]
+	return dataset, ok
+}
+
+func (ws *WebServer) listDatasets() []*data.Dataset {
+	ws.datasetsMu.Lock()
+	defer ws.datasetsMu.Unlock()
+	
+	datasets := make([]*data.Dataset, 0, len(ws.datasets))
+	for _, dataset := range ws.datasets {
+		datasets = append(datasets, dataset)
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].Name < datasets[j].Name
+	})
+	return datasets
+}
+
 func (ws *WebServer) handleGetResults(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -215,24 +815,105 @@ func (ws *WebServer) handleExportMarkdown(w http.ResponseWriter, r *http.Request
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 	w.Header().Set("Content-Type", "text/markdown")
 	
-	if err := export.ExportToMarkdown(results, ""); err != nil {
+	if err := export.ExportToMarkdownWithPlan(results, ws.benchmarkSuite.GetPlan(), ""); err != nil {
 		http.Error(w, fmt.Sprintf("Export failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 }
 
-func (ws *WebServer) parseArrayType(arrayType string) data.ArrayType {
-	switch arrayType {
-	case "sorted":
-		return data.Sorted
-	case "reverse":
-		return data.ReverseSorted
-	default:
-		return data.Random
+func (ws *WebServer) parseArray(arrayType, arrayParams string) (data.ArrayType, data.ArrayParams, error) {
+	parsedType := data.Random
+	if arrayType != "" {
+		parsed, err := data.ParseArrayType(arrayType)
+		if err != nil {
+			return parsedType, data.ArrayParams{}, err
+		}
+		parsedType = parsed
+	}
+	
+	params, err := data.ParseArrayParams(arrayParams)
+	return parsedType, params, err
+}
+
+func (ws *WebServer) parseSortOptions(pivot, partition string) (algorithms.SortOptions, error) {
+	options := algorithms.SortOptions{
+		Pivot:     algorithms.PivotLast,
+		Partition: algorithms.PartitionLomuto,
+	}
+	if pivot != "" {
+		parsed, err := algorithms.ParsePivotStrategy(pivot)
+		if err != nil {
+			return options, err
+		}
+		options.Pivot = parsed
+	}
+	if partition != "" {
+		parsed, err := algorithms.ParsePartitionScheme(partition)
+		if err != nil {
+			return options, err
+		}
+		options.Partition = parsed
+	}
+	return options, nil
+}
+
+func (ws *WebServer) parseWorkload(queries int, hitRatio *float64, distribution string) (benchmark.SearchWorkload, error) {
+	workload := benchmark.DefaultSearchWorkload()
+	if queries > 0 {
+		workload.Queries = queries
+	}
+	if hitRatio != nil {
+		if *hitRatio < 0 || *hitRatio > 1 {
+			return workload, fmt.Errorf("hit ratio must be between 0 and 1: %g", *hitRatio)
+		}
+		workload.HitRatio = *hitRatio
+	}
+	if distribution != "" {
+		parsed, err := data.ParseQueryDistribution(distribution)
+		if err != nil {
+			return workload, err
+		}
+		workload.Distribution = parsed
+	}
+	return workload, nil
+}
+
+func (ws *WebServer) parseAdaptiveRuns(precision float64, minRuns, maxRuns int, timeBudget string) (benchmark.AdaptiveRuns, error) {
+	if precision == 0 {
+		return benchmark.AdaptiveRuns{}, nil
+	}
+	
+	adaptive := benchmark.DefaultAdaptiveRuns()
+	adaptive.TargetPrecision = precision
+	if minRuns > 0 {
+		adaptive.MinRuns = minRuns
+	}
+	if maxRuns > 0 {
+		adaptive.MaxRuns = maxRuns
+	}
+	if timeBudget != "" {
+		budget, err := time.ParseDuration(timeBudget)
+		if err != nil {
+			return adaptive, fmt.Errorf("invalid time budget: %s", timeBudget)
+		}
+		adaptive.TimeBudget = budget
+	}
+	return adaptive, nil
+}
+
+func (ws *WebServer) parseTimeout(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
 	}
+	return timeout, nil
 }
 
-func (ws *WebServer) sendJSONResponse(w http.ResponseWriter, response BenchmarkResponse, statusCode int) {
+func (ws *WebServer) sendJSONResponse(w http.ResponseWriter, response interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)